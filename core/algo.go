@@ -3,6 +3,7 @@ package core
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,17 +24,32 @@ type Algo struct {
 	status         OCStatus
 	statusChannel  chan OCStatus
 	ackChannel     chan bool
-	notifChannel   chan OCStatus
+	taskChannel    chan task
+	runDone        chan struct{} // closed when the run go routine exits
+	changed        chan struct{} // closed and renewed at each status or model change
+	notified       chan struct{} // closed when the status notification loop exits
+	closed         bool          // set by Close
+	subscriptions  map[<-chan Event]*subscription
+	unsubscribed   bool // set by Close, guarded by the subscribe mutex
 	runtimeFigures RuntimeFigures
 	newData        int
 	pushedData     int
 	iterations     int
+	version        int // model version, incremented at each centroids change
+	history        []HistoryEntry
+	trace          []TraceRecord
 	duration       time.Duration
-	lastDataTime   int64
+	lastDataTime   int64 // last push time in nanoseconds since the Unix epoch
 	timeout        Timeout
+	view           atomic.Value // last published *ModelView
 
 	modelMutex  sync.RWMutex // algo model mutex
-	statusMutex sync.RWMutex // algo model mutex
+	statusMutex sync.RWMutex // algo status mutex
+	ctrlMutex   sync.Mutex   // algo controller mutex
+	changeMutex sync.Mutex   // algo change signal mutex
+	viewMutex   sync.Mutex   // algo view publication mutex
+
+	subscribeMutex sync.RWMutex // algo subscriptions mutex
 }
 
 // NewAlgo creates a new algorithm instance
@@ -51,8 +67,15 @@ func NewAlgo(conf Conf, impl Impl, space Space) (algo *Algo) {
 		status:         OCStatus{Value: Created},
 		statusChannel:  make(chan OCStatus),
 		ackChannel:     make(chan bool),
+		taskChannel:    make(chan task),
+		changed:        make(chan struct{}),
 		runtimeFigures: RuntimeFigures{},
 	}
+	algo.view.Store(&ModelView{
+		RuntimeFigures: RuntimeFigures{},
+		Status:         algo.status,
+		Space:          space,
+	})
 
 	return
 }
@@ -62,24 +85,56 @@ func (algo *Algo) setStatus(status OCStatus, safe bool) {
 	if safe {
 		algo.statusMutex.Lock()
 		algo.status = status
+		algo.publishStatus(status)
 		algo.statusMutex.Unlock()
 	} else {
 		algo.status = status
+		algo.publishStatus(status)
 	}
-	algo.notifChannel <- status
+	algo.signal()
+	algo.publish(algo.newEvent(StatusEvent, status))
 }
 
-// receiveStatus status from main routine
-func (algo *Algo) receiveStatus() {
-	var status = <-algo.statusChannel
+// signal wakes up all go routines waiting for a status or model change
+func (algo *Algo) signal() {
+	algo.changeMutex.Lock()
+	defer algo.changeMutex.Unlock()
+	close(algo.changed)
+	algo.changed = make(chan struct{})
+}
+
+// changes returns a channel closed at the next status or model change
+func (algo *Algo) changes() <-chan struct{} {
+	algo.changeMutex.Lock()
+	defer algo.changeMutex.Unlock()
+	return algo.changed
+}
+
+// applyStatus received from main routine. The status is acknowledged unless the run go routine exits
+func (algo *Algo) applyStatus(status OCStatus) OCStatus {
 	algo.setStatus(status, true)
-	algo.ackChannel <- true
+	if status.Running() {
+		algo.ackChannel <- true
+	}
+	return status
 }
 
-// sendStatus status to run go routine
-func (algo *Algo) sendStatus(status OCStatus) (ok bool) {
-	algo.statusChannel <- status
-	_, ok = <-algo.ackChannel
+// receiveStatus status from main routine
+func (algo *Algo) receiveStatus() OCStatus {
+	return algo.applyStatus(<-algo.statusChannel)
+}
+
+// sendStatus status to run go routine. Returns false if the run go routine exited before receiving the status
+func (algo *Algo) sendStatus(status OCStatus, done <-chan struct{}) (ok bool) {
+	select {
+	case algo.statusChannel <- status:
+		ok = true
+		select { // wait for acknowledgement or run go routine exit
+		case <-algo.ackChannel:
+		case <-done:
+		}
+	case <-done:
+	}
 	return
 }
 
@@ -92,12 +147,16 @@ func (algo *Algo) notify(status OCStatus) {
 	}
 }
 
-func (algo *Algo) notificationLoop() {
-	for {
-		var status, ok = <-algo.notifChannel
-		if !ok {
-			return
-		}
-		algo.notify(status)
+// startNotification starts forwarding status events to the configured status notifier
+func (algo *Algo) startNotification() {
+	var events = algo.Subscribe(EventFilter{Types: StatusEvent, Policy: BlockEvents})
+	algo.notified = make(chan struct{})
+	go algo.notificationLoop(events, algo.notified)
+}
+
+func (algo *Algo) notificationLoop(events <-chan Event, notified chan struct{}) {
+	defer close(notified)
+	for event := range events {
+		algo.notify(event.Status)
 	}
 }
@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"context"
 	"errors"
 	"math"
 	"testing"
@@ -233,7 +234,7 @@ func TestIterError(t *testing.T) {
 
 	err = algo.Wait(nil, 0)
 
-	if err != errIter && err != nil {
+	if !errors.Is(err, errIter) && err != nil {
 		t.Error("iter error or not running expected", err)
 	}
 
@@ -245,9 +246,17 @@ func TestIterError(t *testing.T) {
 
 	err = algo.Batch()
 
-	if err != errIter {
+	if !errors.Is(err, errIter) {
 		t.Error("Iter error expected", err)
 	}
+
+	var iterErr *core.IterationError
+	if !errors.As(algo.Status().Error, &iterErr) || iterErr.Iteration != 1 || iterErr.Status.Value != core.Running {
+		t.Error("iteration error expected", algo.Status().Error)
+	}
+	if iterErr != nil && (iterErr.Impl != "*core_test.mockImpl" || iterErr.Stack != nil) {
+		t.Error("impl name without stack expected", iterErr.Impl)
+	}
 }
 
 func TestPause(t *testing.T) {
@@ -447,24 +456,311 @@ func Test_StatusNotifier(t *testing.T) {
 	}
 }
 
-/*
+func Test_PredictBatch(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 10)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var elemts = make([]core.Elemt, 7)
+	centroids, labels, dists, version := algo.PredictBatch(elemts, 3)
+
+	if len(centroids) != len(algo.Centroids()) {
+		t.Error("centroids expected", centroids)
+	}
+	if len(labels) != 7 || len(dists) != 7 {
+		t.Error("7 predictions expected", labels, dists)
+	}
+	if version != algo.Version() {
+		t.Error("current version expected", version)
+	}
+
+	_, _, _, version = algo.PredictBatch(elemts, 0)
+
+	if version != algo.Version() {
+		t.Error("same version expected", version)
+	}
+}
+
 func Test_SetConf(t *testing.T) {
-	algo := newAlgo(t, core.CtrlConf{Iter: 1000}, 10)
+	algo := newAlgo(t, core.CtrlConf{}, 10)
 
 	test.DoTestSetConf(t, &algo)
 }
-*/
 
-/*
 func Test_SetSpace(t *testing.T) {
-	algo := newAlgo(t, core.CtrlConf{Iter: 1000}, 10)
+	algo := newAlgo(t, core.CtrlConf{}, 10)
 
 	test.DoTestSetSpace(t, &algo)
 }
-*/
+
+func Test_SetConfRunning(t *testing.T) {
+	algo := newAlgo(t, core.CtrlConf{}, 10)
+
+	var err = algo.Play()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	err = algo.SetConf(&mockConf{CtrlConf: core.CtrlConf{Iter: 10}})
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	err = algo.Wait(nil, 0)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if algo.Status().Value != core.Ready {
+		t.Error("ready expected", algo.Status().Value)
+	}
+
+	if algo.RuntimeFigures()[core.Iterations] < 10 {
+		t.Error("10 iterations expected", algo.RuntimeFigures()[core.Iterations])
+	}
+
+	err = algo.SetConf(&mockConf{CtrlConf: core.CtrlConf{IterFreq: -1}})
+
+	if err == nil {
+		t.Error("error expected")
+	}
+}
 
 func Test_IterToRun(t *testing.T) {
 	algo := newAlgo(t, core.CtrlConf{}, 10)
 
 	test.DoTestIterToRun(t, &algo)
 }
+
+func Test_PlayContext(t *testing.T) {
+	algo := newAlgo(t, core.CtrlConf{}, 10)
+
+	var ctx, cancel = context.WithCancel(context.Background())
+
+	var err = algo.PlayContext(ctx)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	cancel()
+
+	err = algo.Wait(core.NewStatusFinishing(false, core.Finished), time.Second)
+
+	if err != context.Canceled {
+		t.Error("canceled expected", err)
+	}
+	if algo.Status().Value != core.Finished {
+		t.Error("finished expected", algo.Status().Value)
+	}
+}
+
+func Test_PlayContextDone(t *testing.T) {
+	algo := newAlgo(t, core.CtrlConf{}, 10)
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	var err = algo.PlayContext(ctx)
+
+	if err != context.Canceled {
+		t.Error("canceled expected", err)
+	}
+	if algo.Status().Value != core.Created {
+		t.Error("created expected", algo.Status().Value)
+	}
+}
+
+func Test_WaitContext(t *testing.T) {
+	algo := newAlgo(t, core.CtrlConf{}, 10)
+
+	var err = algo.Play()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = algo.WaitContext(ctx, nil)
+
+	if err != context.DeadlineExceeded {
+		t.Error("deadline exceeded expected", err)
+	}
+	if algo.Status().Value != core.Running {
+		t.Error("running expected", algo.Status().Value)
+	}
+
+	err = algo.WaitContext(context.Background(), nil)
+
+	if err != core.ErrNeverFinish {
+		t.Error("never finish expected", err)
+	}
+
+	_ = algo.Stop()
+}
+
+func Test_BatchContext(t *testing.T) {
+	algo := newAlgo(t, core.CtrlConf{}, 10)
+
+	var ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var err = algo.BatchContext(ctx)
+
+	if err != context.DeadlineExceeded {
+		t.Error("deadline exceeded expected", err)
+	}
+	if algo.Status().Value != core.Finished {
+		t.Error("finished expected", algo.Status().Value)
+	}
+
+	algo.Conf().Ctrl().Iter = 10
+
+	err = algo.BatchContext(context.Background())
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	if iter := algo.RuntimeFigures()[core.Iterations]; iter != 10 {
+		t.Error("10 iterations expected", iter)
+	}
+}
+
+func Test_PushWeighted(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 1}, 3)
+
+	if err := algo.PushWeighted([]float64{1.}, 0); err != core.ErrWeight {
+		t.Error("weight error expected", err)
+	}
+	if err := algo.PushWeighted([]float64{1.}, 2); err != core.ErrNotWeighted {
+		t.Error("not weighted error expected", err)
+	}
+	if pushed := algo.RuntimeFigures()[core.PushedData]; pushed != 0 {
+		t.Error("no pushed data expected", pushed)
+	}
+}
+
+func Test_Reasons(t *testing.T) {
+	var reasonOf = func(conf core.CtrlConf, size int, stop bool) core.Reason {
+		var algo = newAlgo(t, conf, size)
+		var err = algo.Play()
+		if err != nil {
+			t.Error("no error expected", err)
+		}
+		if stop {
+			_ = algo.Stop()
+		}
+		_ = algo.Wait(core.NewStatusFinishing(false, core.Ready, core.Finished), time.Second)
+		return algo.Status().Reason
+	}
+
+	if reason := reasonOf(core.CtrlConf{Iter: 5}, 3, false); reason != core.Exhausted {
+		t.Error("exhausted expected", reason)
+	}
+	if reason := reasonOf(core.CtrlConf{Finishing: core.NewIterFinishing(5, 0)}, 3, false); reason != core.Converged {
+		t.Error("converged expected", reason)
+	}
+	if reason := reasonOf(core.CtrlConf{Timeout: 10 * time.Millisecond}, 3, false); reason != core.TimedOut {
+		t.Error("timed out expected", reason)
+	}
+	if reason := reasonOf(core.CtrlConf{}, 3, true); reason != core.Stopped {
+		t.Error("stopped expected", reason)
+	}
+	if reason := reasonOf(core.CtrlConf{Iter: 5}, 2, false); reason != core.Failed {
+		t.Error("failed expected", reason)
+	}
+}
+
+func Test_TimeoutAfterFinish(t *testing.T) {
+	var finishedBy = func(conf core.CtrlConf, reason core.Reason) {
+		var algo = newAlgo(t, conf, 3)
+		if err := algo.Play(); err != nil {
+			t.Error("no error expected", err)
+		}
+		_ = algo.Wait(core.NewStatusFinishing(false, core.Ready, core.Finished), time.Second)
+		time.Sleep(2 * conf.Timeout)
+		if status := algo.Status(); status.Value != core.Ready || status.Reason != reason || status.Error != nil {
+			t.Error("ready expected after the timeout", reason, status)
+		}
+		_ = algo.Close()
+	}
+
+	finishedBy(core.CtrlConf{Iter: 3, Timeout: 50 * time.Millisecond}, core.Exhausted)
+	finishedBy(core.CtrlConf{Finishing: core.NewIterFinishing(3, 0), Timeout: 50 * time.Millisecond}, core.Converged)
+}
+
+func Test_BatchReason(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 3)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	if status := algo.Status(); status.Value != core.Finished || status.Reason != core.Exhausted || !status.Terminated() {
+		t.Error("finished and exhausted expected", status)
+	}
+
+	err = algo.Play()
+
+	if err != nil || algo.Status().Terminated() {
+		t.Error("running without reason expected", algo.Status(), err)
+	}
+
+	err = algo.Wait(core.NewReasonFinishing(core.Exhausted, core.Converged), time.Second)
+
+	if err != nil || algo.Status().Reason != core.Exhausted {
+		t.Error("exhausted expected", algo.Status(), err)
+	}
+}
+
+func Test_PushBatch(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 1, DataPerIter: 2}, 3)
+	var events = algo.Subscribe(core.EventFilter{Types: core.PushEvent})
+
+	var err = algo.Init()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	err = algo.PushBatch(test.Vectors[:3])
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	if pushed := algo.RuntimeFigures()[core.PushedData]; pushed != 3 {
+		t.Error("3 pushed data expected", pushed)
+	}
+	if len(algo.Impl().(*mockImpl).clust) != 6 {
+		t.Error("pushed elements expected")
+	}
+	for i := 0; i < 3; i++ {
+		if event := <-events; event.Elemt == nil {
+			t.Error("push event expected", event)
+		}
+	}
+	if status := algo.Status().Value; status == core.Ready {
+		t.Error("played algorithm expected", status)
+	}
+	_ = algo.Stop()
+}
+
+func Test_DeleteNotDeletable(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{}, 3)
+
+	var count, err = algo.Delete(func(core.Elemt) bool { return true })
+
+	if err != core.ErrNotDeletable || count != 0 {
+		t.Error("not deletable error expected", count, err)
+	}
+}
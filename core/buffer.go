@@ -1,10 +1,23 @@
 package core
 
+import (
+	"encoding/gob"
+	"time"
+)
+
 // Buffer interface
 type Buffer interface {
 	Push(elemt Elemt, running bool) error
+	PushWeighted(elemt Elemt, weight float64, running bool) error
+	PushBatch(elemts []Elemt, running bool) []error
 	Data() []Elemt
+	Weights() []float64
 	Apply() error
+	Snapshot(*gob.Encoder) error
+	Restore(*gob.Decoder) error
+	Staging() *Staging
+	Delete(predicate func(Elemt) bool) (elemts []Elemt, weights []float64, staged int)
+	Ages() (oldest time.Duration, newest time.Duration, timed bool)
 }
 
 // DataBuffer that stores data.
@@ -12,8 +25,9 @@ type Buffer interface {
 // In asynchronous mode, when pushed() is called data are staged.
 // Staged data are stored when apply() is called.
 type DataBuffer struct {
-	pipe     chan Elemt
+	staging  *Staging
 	data     []Elemt
+	weights  []float64
 	strategy bufferSizeStrategy
 }
 
@@ -23,8 +37,22 @@ const pipeSize = 2000
 // NewDataBuffer creates a fixed size buffer if given size > 0.
 // Otherwise creates an infinite size buffer.
 func NewDataBuffer(data []Elemt, size int) Buffer {
+	return NewWeightedDataBuffer(data, nil, size)
+}
+
+// NewWeightedDataBuffer creates a buffer with weighted data.
+// All weights are 1 if weights is nil
+func NewWeightedDataBuffer(data []Elemt, weights []float64, size int) Buffer {
+	return NewStagedDataBuffer(data, weights, size, NewStaging(pipeSize, DefaultOverflow, 0))
+}
+
+// NewStagedDataBuffer creates a buffer with weighted data which stages pushed data in the given staging queue
+func NewStagedDataBuffer(data []Elemt, weights []float64, size int, staging *Staging) Buffer {
 	var db = DataBuffer{
-		pipe: make(chan Elemt, pipeSize),
+		staging: staging,
+	}
+	if weights == nil {
+		weights = UnitWeights(len(data))
 	}
 
 	switch {
@@ -32,19 +60,25 @@ func NewDataBuffer(data []Elemt, size int) Buffer {
 		// fixed size buffer, less data than buffer size
 		db.strategy = &fixedSizeStrategy{size, len(data)}
 		db.data = make([]Elemt, len(data), size)
+		db.weights = make([]float64, len(data), size)
 		copy(db.data[:len(data)], data)
+		copy(db.weights, weights)
 
 	case size > 0:
 		// fixed size buffer, more data than buffer size
 		db.strategy = &fixedSizeStrategy{size, size}
 		db.data = make([]Elemt, size)
+		db.weights = make([]float64, size)
 		copy(db.data, data[len(data)-size:])
+		copy(db.weights, weights[len(weights)-size:])
 
 	default:
 		// infinite buffer
 		db.strategy = &infiniteSizeStrategy{}
 		db.data = make([]Elemt, len(data))
+		db.weights = make([]float64, len(data))
 		copy(db.data, data)
+		copy(db.weights, weights)
 	}
 
 	return &db
@@ -52,10 +86,28 @@ func NewDataBuffer(data []Elemt, size int) Buffer {
 
 // Push stores or stages an element depending on synchronous / asynchronous mode.
 func (b *DataBuffer) Push(elmt Elemt, running bool) (err error) {
+	return b.PushWeighted(elmt, 1, running)
+}
+
+// PushWeighted stores or stages an element with its weight
+func (b *DataBuffer) PushWeighted(elmt Elemt, weight float64, running bool) (err error) {
 	if running {
-		b.pipe <- elmt
+		err = b.staging.Push(elmt, weight)
 	} else {
-		b.data = b.strategy.push(b.data, elmt)
+		b.data, b.weights = b.strategy.push(b.data, b.weights, elmt, weight, time.Now().UnixNano())
+	}
+	return
+}
+
+// PushBatch stores or stages elements. Returns the error of each element, or nil if all elements are pushed
+func (b *DataBuffer) PushBatch(elemts []Elemt, running bool) (errs []error) {
+	for i, elemt := range elemts {
+		if err := b.PushWeighted(elemt, 1, running); err != nil {
+			if errs == nil {
+				errs = make([]error, len(elemts))
+			}
+			errs[i] = err
+		}
 	}
 	return
 }
@@ -65,32 +117,112 @@ func (b *DataBuffer) Data() (data []Elemt) {
 	return b.data
 }
 
-// Apply all staged data in asynchronous mode, otherwise do nothing
+// Weights returns the weights of buffer data
+func (b *DataBuffer) Weights() (weights []float64) {
+	return b.weights
+}
+
+// Apply all staged data in asynchronous mode, then evict expired data of a time-to-live buffer
 func (b *DataBuffer) Apply() (err error) {
 	for b.applyNext() {
 	}
+	b.data, b.weights = b.strategy.evict(b.data, b.weights)
 	return
 }
 
 // Applies next staged data if available and returns true.
 // Otherwise returns false.
 func (b *DataBuffer) applyNext() (ok bool) {
-	var elmt Elemt
+	var elmt WeightedElemt
+	if elmt, ok = b.staging.Next(); ok {
+		b.data, b.weights = b.strategy.push(b.data, b.weights, elmt.Elemt, elmt.Weight, elmt.Time)
+	}
+	return
+}
 
-	select {
-	case elmt, ok = <-b.pipe:
-		if ok {
-			b.data = b.strategy.push(b.data, elmt)
-		}
-	default:
+// Delete removes buffered and staged data matching the predicate.
+// Returns the removed buffered data with their weights, and the number of removed staged data
+func (b *DataBuffer) Delete(predicate func(Elemt) bool) (elemts []Elemt, weights []float64, staged int) {
+	staged = len(b.staging.Delete(predicate))
+	b.data, b.weights, elemts, weights = b.strategy.delete(b.data, b.weights, predicate)
+	return
+}
+
+// Ages returns the ages of the oldest and newest data of a time-to-live buffer. Timed is false for other buffers
+func (b *DataBuffer) Ages() (oldest time.Duration, newest time.Duration, timed bool) {
+	var strategy *ttlStrategy
+	if strategy, timed = b.strategy.(*ttlStrategy); timed {
+		oldest, newest = strategy.ages()
+	}
+	return
+}
+
+// Staging returns the staging queue of data pushed while running
+func (b *DataBuffer) Staging() *Staging {
+	return b.staging
+}
+
+// bufferSnapshot is the saved state of a data buffer
+type bufferSnapshot struct {
+	Data     []Elemt
+	Weights  []float64
+	Size     int           // size of a fixed size buffer, 0 for an infinite buffer
+	Position int           // next position of a fixed size buffer
+	TTL      time.Duration // time-to-live of a timed buffer
+	Times    []int64       // push times of a timed buffer data
+	Staged   []WeightedElemt
+}
+
+// Snapshot writes buffer data and staged data, which stay staged
+func (b *DataBuffer) Snapshot(encoder *gob.Encoder) error {
+	var snapshot = bufferSnapshot{Data: b.data, Weights: b.weights, Staged: b.staging.Staged()}
+	switch strategy := b.strategy.(type) {
+	case *fixedSizeStrategy:
+		snapshot.Size = strategy.size
+		snapshot.Position = strategy.position
+	case *ttlStrategy:
+		snapshot.TTL = strategy.ttl
+		snapshot.Times = strategy.times
 	}
+	return encoder.Encode(snapshot)
+}
 
+// Restore replaces buffer data with data written by Snapshot, and stages the saved staged data
+func (b *DataBuffer) Restore(decoder *gob.Decoder) (err error) {
+	var snapshot bufferSnapshot
+	if err = decoder.Decode(&snapshot); err == nil {
+		b.staging.restage(snapshot.Staged)
+		if snapshot.Weights == nil {
+			snapshot.Weights = UnitWeights(len(snapshot.Data))
+		}
+		switch {
+		case snapshot.TTL > 0:
+			var strategy = &ttlStrategy{ttl: snapshot.TTL, times: snapshot.Times}
+			strategy.publish()
+			b.strategy = strategy
+			b.data = snapshot.Data
+			b.weights = snapshot.Weights
+		case snapshot.Size > 0:
+			b.strategy = &fixedSizeStrategy{snapshot.Size, snapshot.Position}
+			b.data = make([]Elemt, len(snapshot.Data), snapshot.Size)
+			b.weights = make([]float64, len(snapshot.Weights), snapshot.Size)
+			copy(b.data, snapshot.Data)
+			copy(b.weights, snapshot.Weights)
+		default:
+			b.strategy = &infiniteSizeStrategy{}
+			b.data = snapshot.Data
+			b.weights = snapshot.Weights
+		}
+	}
 	return
 }
 
 // Handle the way data are stored, i.e. infinite or fixed size buffer.
+// Elements are pushed with their push time in nanoseconds since the Unix epoch
 type bufferSizeStrategy interface {
-	push(data []Elemt, elemt Elemt) []Elemt
+	push(data []Elemt, weights []float64, elemt Elemt, weight float64, at int64) ([]Elemt, []float64)
+	delete(data []Elemt, weights []float64, predicate func(Elemt) bool) (kept []Elemt, keptWeights []float64, deleted []Elemt, deletedWeights []float64)
+	evict(data []Elemt, weights []float64) ([]Elemt, []float64)
 }
 
 // Fixed size buffer
@@ -99,26 +231,76 @@ type fixedSizeStrategy struct {
 	position int
 }
 
-func (s *fixedSizeStrategy) push(data []Elemt, elemt Elemt) []Elemt {
+func (s *fixedSizeStrategy) push(data []Elemt, weights []float64, elemt Elemt, weight float64, _ int64) ([]Elemt, []float64) {
 	if s.position == s.size {
 		s.position = 0
 	}
 
 	if s.position < len(data) {
 		data[s.position] = elemt
+		weights[s.position] = weight
 	} else {
 		data = append(data, elemt)
+		weights = append(weights, weight)
 	}
 
 	s.position++
 
-	return data
+	return data, weights
+}
+
+// delete data of a fixed size buffer, keeping the remaining data from the oldest to the newest
+func (s *fixedSizeStrategy) delete(data []Elemt, weights []float64, predicate func(Elemt) bool) ([]Elemt, []float64, []Elemt, []float64) {
+	var ordered = make([]Elemt, 0, s.size)
+	var orderedWeights = make([]float64, 0, s.size)
+	if len(data) == s.size { // the oldest data is at the current position
+		ordered = append(append(ordered, data[s.position:]...), data[:s.position]...)
+		orderedWeights = append(append(orderedWeights, weights[s.position:]...), weights[:s.position]...)
+	} else {
+		ordered = append(ordered, data...)
+		orderedWeights = append(orderedWeights, weights...)
+	}
+	var kept, keptWeights, deleted, deletedWeights = filterData(ordered, orderedWeights, func(i int) bool { return predicate(ordered[i]) })
+	s.position = len(kept)
+	return kept, keptWeights, deleted, deletedWeights
+}
+
+func (s *fixedSizeStrategy) evict(data []Elemt, weights []float64) ([]Elemt, []float64) {
+	return data, weights
 }
 
 // Infinite size buffer
 type infiniteSizeStrategy struct {
 }
 
-func (s *infiniteSizeStrategy) push(data []Elemt, elemt Elemt) []Elemt {
-	return append(data, elemt)
+func (s *infiniteSizeStrategy) push(data []Elemt, weights []float64, elemt Elemt, weight float64, _ int64) ([]Elemt, []float64) {
+	return append(data, elemt), append(weights, weight)
+}
+
+func (s *infiniteSizeStrategy) evict(data []Elemt, weights []float64) ([]Elemt, []float64) {
+	return data, weights
+}
+
+func (s *infiniteSizeStrategy) delete(data []Elemt, weights []float64, predicate func(Elemt) bool) ([]Elemt, []float64, []Elemt, []float64) {
+	return filterData(data, weights, func(i int) bool { return predicate(data[i]) })
+}
+
+// filterData removes in place the data at the removed indices, and returns them in new slices.
+// The removed function is called with increasing indices, before the data at the index is moved
+func filterData(data []Elemt, weights []float64, removed func(int) bool) (kept []Elemt, keptWeights []float64, deleted []Elemt, deletedWeights []float64) {
+	var n = 0
+	for i, elemt := range data {
+		if removed(i) {
+			deleted = append(deleted, elemt)
+			deletedWeights = append(deletedWeights, weights[i])
+		} else {
+			data[n] = elemt
+			weights[n] = weights[i]
+			n++
+		}
+	}
+	for i := n; i < len(data); i++ { // release deleted data
+		data[i] = nil
+	}
+	return data[:n], weights[:n], deleted, deletedWeights
 }
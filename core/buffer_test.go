@@ -1,6 +1,8 @@
 package core_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"reflect"
 	"testing"
 
@@ -117,3 +119,103 @@ func TestBuffer_Apply(t *testing.T) {
 		t.Error("Expected 256 got", l)
 	}
 }
+
+func TestBuffer_Snapshot(t *testing.T) {
+	elemts := []core.Elemt{[]float64{0.}, []float64{1.}}
+	var buf = core.NewDataBuffer(elemts, 3)
+
+	for i := 2; i < 5; i++ {
+		_ = buf.Push([]float64{float64(i)}, true)
+	}
+
+	var b bytes.Buffer
+	if err := buf.Snapshot(gob.NewEncoder(&b)); err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var restored = core.NewDataBuffer(nil, 0)
+	if err := restored.Restore(gob.NewDecoder(&b)); err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if !reflect.DeepEqual(restored.Data(), buf.Data()) {
+		t.Error("Expected", buf.Data(), "got", restored.Data())
+	}
+	if buf.Staging().Len() != 3 || restored.Staging().Len() != 3 {
+		t.Error("staged data expected", buf.Staging().Len(), restored.Staging().Len())
+	}
+
+	_ = buf.Apply()
+	_ = restored.Apply()
+
+	if !reflect.DeepEqual(restored.Data(), buf.Data()) {
+		t.Error("Expected", buf.Data(), "got", restored.Data())
+	}
+
+	_ = buf.Push([]float64{5.}, false)
+	_ = restored.Push([]float64{5.}, false)
+
+	if !reflect.DeepEqual(restored.Data(), buf.Data()) {
+		t.Error("Expected", buf.Data(), "got", restored.Data())
+	}
+}
+
+func TestBuffer_PushWeighted(t *testing.T) {
+	elemts := []core.Elemt{[]float64{1.2, 3.2}, []float64{1.2, 3.2}}
+	var buf = core.NewWeightedDataBuffer(elemts, []float64{2., 3.}, 3)
+
+	_ = buf.Push([]float64{1.}, false)
+	_ = buf.PushWeighted([]float64{2.}, 4., false)
+	_ = buf.PushWeighted([]float64{3.}, .5, true)
+	_ = buf.Apply()
+
+	if w := buf.Weights(); !reflect.DeepEqual(w, []float64{4., .5, 1.}) {
+		t.Error("Expected [4 .5 1] got", w)
+	}
+	if l := len(buf.Data()); l != 3 {
+		t.Error("Expected 3 got", l)
+	}
+}
+
+func TestBuffer_Delete(t *testing.T) {
+	var odd = func(elemt core.Elemt) bool {
+		return int(elemt.([]float64)[0])%2 == 1
+	}
+	var infinite = core.NewWeightedDataBuffer(nil, nil, 0)
+	var fixed = core.NewWeightedDataBuffer(nil, nil, 4)
+	for i := 0; i < 6; i++ {
+		_ = infinite.PushWeighted([]float64{float64(i)}, float64(i+1), false)
+		_ = fixed.PushWeighted([]float64{float64(i)}, float64(i+1), false)
+	}
+	_ = infinite.Push([]float64{7.}, true)
+	_ = infinite.Push([]float64{8.}, true)
+
+	var elemts, weights, staged = infinite.Delete(odd)
+
+	if !reflect.DeepEqual(elemts, []core.Elemt{[]float64{1.}, []float64{3.}, []float64{5.}}) || !reflect.DeepEqual(weights, []float64{2., 4., 6.}) || staged != 1 {
+		t.Error("odd data expected", elemts, weights, staged)
+	}
+	if !reflect.DeepEqual(infinite.Data(), []core.Elemt{[]float64{0.}, []float64{2.}, []float64{4.}}) || !reflect.DeepEqual(infinite.Weights(), []float64{1., 3., 5.}) {
+		t.Error("even data expected", infinite.Data(), infinite.Weights())
+	}
+	if infinite.Staging().Len() != 1 {
+		t.Error("even staged data expected", infinite.Staging().Len())
+	}
+
+	elemts, _, staged = fixed.Delete(odd)
+
+	if !reflect.DeepEqual(elemts, []core.Elemt{[]float64{3.}, []float64{5.}}) || staged != 0 {
+		t.Error("odd data expected", elemts, staged)
+	}
+	if !reflect.DeepEqual(fixed.Data(), []core.Elemt{[]float64{2.}, []float64{4.}}) || !reflect.DeepEqual(fixed.Weights(), []float64{3., 5.}) {
+		t.Error("even data from the oldest expected", fixed.Data(), fixed.Weights())
+	}
+
+	for i := 6; i < 9; i++ {
+		_ = fixed.Push([]float64{float64(i)}, false)
+	}
+
+	if !reflect.DeepEqual(fixed.Data(), []core.Elemt{[]float64{8.}, []float64{4.}, []float64{6.}, []float64{7.}}) {
+		t.Error("oldest data replaced expected", fixed.Data())
+	}
+}
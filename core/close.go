@@ -0,0 +1,48 @@
+package core
+
+// Close stops the algorithm, cancels its timeout, and closes the event subscriptions once the status notifier received the pending events.
+// Every later control call returns ErrClosed
+func (algo *Algo) Close() (err error) {
+	algo.ctrlMutex.Lock()
+	if err = algo.closedError(); err != nil {
+		algo.ctrlMutex.Unlock()
+		return
+	}
+	algo.statusMutex.RLock()
+	var done, notified = algo.runDone, algo.notified
+	algo.statusMutex.RUnlock()
+	if algo.timeout != nil { // a timeout must not race the closing interruption
+		algo.timeout.Disable()
+	}
+	_ = algo.interrupt(nil)
+	if done != nil { // wait for the run go routine exit
+		<-done
+	}
+	algo.statusMutex.Lock()
+	algo.closed = true
+	algo.statusMutex.Unlock()
+	algo.ctrlMutex.Unlock()
+
+	algo.subscribeMutex.Lock()
+	var subs = algo.subscriptions
+	algo.subscriptions = nil
+	algo.unsubscribed = true
+	algo.subscribeMutex.Unlock()
+	for _, sub := range subs {
+		sub.close()
+	}
+	if notified != nil { // wait for the status notifier to drain pending events
+		<-notified
+	}
+	return
+}
+
+// closedError returns ErrClosed if the algorithm is closed
+func (algo *Algo) closedError() (err error) {
+	algo.statusMutex.RLock()
+	defer algo.statusMutex.RUnlock()
+	if algo.closed {
+		err = ErrClosed
+	}
+	return
+}
@@ -0,0 +1,118 @@
+package core_test
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+// waitGoroutines waits for the number of go routines to go back to an expected value
+func waitGoroutines(expected int) (count int) {
+	for i := 0; i < 100; i++ {
+		if count = runtime.NumGoroutine(); count <= expected {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return
+}
+
+func Test_Close(t *testing.T) {
+	var goroutines = runtime.NumGoroutine()
+	var notified = 0
+	var algo = newAlgo(t, core.CtrlConf{
+		Timeout:        time.Hour,
+		StatusNotifier: func(core.OnlineClust, core.OCStatus) { notified++ },
+	}, 10)
+	var events = algo.Subscribe(core.EventFilter{})
+
+	var err = algo.Play()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	err = algo.Close()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	if status := algo.Status(); status.Value != core.Finished || status.Reason != core.Stopped {
+		t.Error("stopped status expected", status)
+	}
+	if notified != 4 {
+		t.Error("initializing, ready, running and finished notifications expected", notified)
+	}
+	for range events {
+	}
+	if count := waitGoroutines(goroutines); count > goroutines {
+		t.Error("released go routines expected", count, goroutines)
+	}
+}
+
+func Test_CloseBeforeTimeout(t *testing.T) {
+	var timeout = 20 * time.Millisecond
+	var algo = newAlgo(t, core.CtrlConf{Timeout: timeout}, 10)
+	_ = algo.Play()
+	_ = algo.Pause()
+
+	var err = algo.Close()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	time.Sleep(2 * timeout)
+	if status := algo.Status(); status.Value != core.Finished || status.Reason != core.Stopped || status.Error != nil {
+		t.Error("stopped status expected after the timeout", status)
+	}
+}
+
+func Test_CallAfterClose(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 10)
+
+	var err = algo.Close()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	for name, call := range map[string]func() error{
+		"close":     algo.Close,
+		"init":      algo.Init,
+		"play":      algo.Play,
+		"pause":     algo.Pause,
+		"stop":      algo.Stop,
+		"batch":     algo.Batch,
+		"wait":      func() error { return algo.Wait(nil, 0) },
+		"push":      func() error { return algo.Push(nil) },
+		"weighted":  func() error { return algo.PushWeighted(nil, 1) },
+		"conf":      func() error { return algo.SetConf(algo.Conf()) },
+		"space":     func() error { return algo.SetSpace(algo.Space()) },
+		"batchpush": func() error { return algo.PushBatch([]core.Elemt{nil}) },
+		"context":   func() error { return algo.PlayContext(context.Background()) },
+		"flush":     algo.Flush,
+		"step": func() (err error) {
+			_, _, err = algo.Step(1)
+			return
+		},
+		"delete": func() (err error) {
+			_, err = algo.Delete(func(core.Elemt) bool { return true })
+			return
+		},
+		"snapshot": func() error { return algo.Snapshot(&bytes.Buffer{}) },
+		"predictor": func() (err error) {
+			_, err = algo.Predictor()
+			return
+		},
+	} {
+		if err = call(); err != core.ErrClosed {
+			t.Error("closed error expected for", name, err)
+		}
+	}
+	if _, ok := <-algo.Subscribe(core.EventFilter{}); ok {
+		t.Error("closed channel expected")
+	}
+}
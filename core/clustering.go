@@ -3,6 +3,8 @@ package core
 import (
 	"errors"
 	"math"
+	"reflect"
+	"sync"
 
 	"github.com/gonum/floats"
 
@@ -15,6 +17,36 @@ type Clust []Elemt
 // Initializer function initializes k centroids from the given elements.
 type Initializer func(k int, elemts []Elemt, space Space, src *rand.Rand) (centroids Clust, err error)
 
+// WeightedInitializer function initializes k centroids from the given elements and their weights.
+type WeightedInitializer func(k int, elemts []Elemt, weights []float64, space Space, src *rand.Rand) (centroids Clust, err error)
+
+var weightedInitializers = map[uintptr]WeightedInitializer{}
+var weightedInitializersMutex sync.RWMutex
+
+// RegisterWeightedInitializer registers the weighted version of an initializer, returned by Initializer.Weighted.
+// The initializer must be a top level function since closures can not be told apart
+func RegisterWeightedInitializer(initializer Initializer, weighted WeightedInitializer) {
+	weightedInitializersMutex.Lock()
+	defer weightedInitializersMutex.Unlock()
+	weightedInitializers[reflect.ValueOf(initializer).Pointer()] = weighted
+}
+
+// Weighted returns the registered weighted version of the initializer if any,
+// otherwise a weighted initializer which ignores the weights
+func (initializer Initializer) Weighted() WeightedInitializer {
+	if initializer != nil {
+		weightedInitializersMutex.RLock()
+		var weighted, ok = weightedInitializers[reflect.ValueOf(initializer).Pointer()]
+		weightedInitializersMutex.RUnlock()
+		if ok {
+			return weighted
+		}
+	}
+	return func(k int, elemts []Elemt, _ []float64, space Space, src *rand.Rand) (Clust, error) {
+		return initializer(k, elemts, space, src)
+	}
+}
+
 // Assign returns the element nearest centroid, its label and the distance to the centroid
 func (c *Clust) Assign(elemt Elemt, space Space) (centroid Elemt, label int, dist float64) {
 	label, dist = c.nearest(elemt, space)
@@ -36,6 +68,24 @@ func (c *Clust) MapLabel(elemts []Elemt, space Space) (labels []int, dists []flo
 	return
 }
 
+// Cardinalities counts the elements nearest to each centroid
+func (c *Clust) Cardinalities(elemts []Elemt, space Space) (cards []int) {
+	return c.WeightedCardinalities(elemts, nil, space)
+}
+
+// WeightedCardinalities sums the weights of the elements nearest to each centroid.
+// All weights are 1 if weights is nil
+func (c *Clust) WeightedCardinalities(elemts []Elemt, weights []float64, space Space) (cards []int) {
+	var totals = make([]float64, len(*c))
+	for i, elemt := range elemts {
+		var label, _ = c.nearest(elemt, space)
+		if label >= 0 {
+			totals[label] += weightAt(weights, i)
+		}
+	}
+	return toCards(totals)
+}
+
 // ParMapLabel assigns elements to centroids in parallel
 func (c *Clust) ParMapLabel(elemts []Elemt, space Space, degree int) (labels []int, dists []float64) {
 	return parMapLabel(*c, elemts, space, degree)
@@ -53,7 +103,7 @@ func (c *Clust) ReduceDBA(elemts []Elemt, space Space) (centroids Clust, cards [
 			centroids[ix] = space.Copy(elemt)
 			cards[ix] = 1
 		} else {
-			centroids[ix] = space.Combine(centroids[ix], cards[ix], elemt, 1)
+			centroids[ix] = WeightedCombine(space, centroids[ix], float64(cards[ix]), elemt, 1)
 			cards[ix]++
 		}
 	}
@@ -61,6 +111,35 @@ func (c *Clust) ReduceDBA(elemts []Elemt, space Space) (centroids Clust, cards [
 	return
 }
 
+// ReduceWeightedDBA computes centroids and total weight of each clusters for given weighted elements.
+// All weights are 1 if weights is nil
+func (c *Clust) ReduceWeightedDBA(elemts []Elemt, weights []float64, space Space) (centroids Clust, totals []float64) {
+	centroids, totals, _ = c.ReduceWeightedDBALoss(elemts, weights, space, 2)
+	return
+}
+
+// ReduceWeightedDBALoss computes centroids and total weight of each clusters for given weighted elements,
+// with the loss of the given centroids accumulated while elements are assigned
+func (c *Clust) ReduceWeightedDBALoss(elemts []Elemt, weights []float64, space Space, norm float64) (centroids Clust, totals []float64, loss float64) {
+	centroids = make(Clust, len(*c))
+	totals = make([]float64, len(*c))
+
+	for i, elemt := range elemts {
+		var _, ix, dist = c.Assign(elemt, space)
+		var weight = weightAt(weights, i)
+		loss += weight * math.Pow(dist, norm)
+
+		if totals[ix] == 0 {
+			centroids[ix] = space.Copy(elemt)
+		} else {
+			centroids[ix] = WeightedCombine(space, centroids[ix], totals[ix], elemt, weight)
+		}
+		totals[ix] += weight
+	}
+
+	return
+}
+
 // ReduceDBAForLabels computes loss and cardinality in each cluster for the given labels
 func (c *Clust) ReduceDBAForLabels(elemts []Elemt, labels []int, space Space) (means []Elemt, cards []int) {
 	means = make([]Elemt, len(*c))
@@ -73,7 +152,7 @@ func (c *Clust) ReduceDBAForLabels(elemts []Elemt, labels []int, space Space) (m
 			means[label] = space.Copy(elemt)
 			cards[label] = 1
 		} else {
-			means[label] = space.Combine(means[label], cards[label], elemt, 1)
+			means[label] = WeightedCombine(space, means[label], float64(cards[label]), elemt, 1)
 			cards[label]++
 		}
 	}
@@ -87,7 +166,76 @@ func (c *Clust) ParReduceDBAForLabels(elemts []Elemt, labels []int, space Space,
 
 // ParReduceDBA computes centroids and cardinality of each clusters for given elements in parallel.
 func (c *Clust) ParReduceDBA(elemts []Elemt, space Space, degree int) (Clust, []int) {
-	return parReduceDBA(*c, elemts, space, degree)
+	var centroids, totals, _ = parReduceDBA(*c, elemts, nil, space, 2, degree)
+	return centroids, toCards(totals)
+}
+
+// ParReduceWeightedDBA computes centroids and total weight of each clusters for given weighted elements in parallel.
+func (c *Clust) ParReduceWeightedDBA(elemts []Elemt, weights []float64, space Space, degree int) (Clust, []float64) {
+	var centroids, totals, _ = parReduceDBA(*c, elemts, weights, space, 2, degree)
+	return centroids, totals
+}
+
+// ParReduceWeightedDBALoss computes centroids, total weight of each clusters and loss of the given centroids in parallel
+func (c *Clust) ParReduceWeightedDBALoss(elemts []Elemt, weights []float64, space Space, norm float64, degree int) (Clust, []float64, float64) {
+	return parReduceDBA(*c, elemts, weights, space, norm, degree)
+}
+
+// StableReduceWeightedDBA computes centroids and total weight of each clusters for given weighted elements in parallel.
+// The result does not depend on the degree of parallelism
+func (c *Clust) StableReduceWeightedDBA(elemts []Elemt, weights []float64, space Space, degree int) (Clust, []float64) {
+	var centroids, totals, _ = stableReduceDBA(*c, elemts, weights, space, 2, degree)
+	return centroids, totals
+}
+
+// StableReduceWeightedDBALoss computes centroids, total weight of each clusters and loss of the given centroids in parallel.
+// The result does not depend on the degree of parallelism
+func (c *Clust) StableReduceWeightedDBALoss(elemts []Elemt, weights []float64, space Space, norm float64, degree int) (Clust, []float64, float64) {
+	return stableReduceDBA(*c, elemts, weights, space, norm, degree)
+}
+
+// RetractDBA returns centroids from which deleted weighted elements are removed.
+// If the space is an Uncombiner, deleted elements are removed from their nearest centroid, otherwise centroids are
+// recomputed from the remaining data. Centroids of clusters without remaining data are dropped
+func (c *Clust) RetractDBA(deleted []Elemt, deletedWeights []float64, data []Elemt, weights []float64, space Space) (centroids Clust) {
+	centroids = make(Clust, 0, len(*c))
+	var uncombiner, ok = space.(Uncombiner)
+	if !ok {
+		var result, totals = c.ReduceWeightedDBA(data, weights, space)
+		for label, total := range totals {
+			if total > 0 {
+				centroids = append(centroids, result[label])
+			}
+		}
+		return
+	}
+	var retracted = make(Clust, len(*c))
+	copy(retracted, *c)
+	var remaining = make([]float64, len(*c))
+	for i, elemt := range data {
+		var _, label, _ = c.Assign(elemt, space)
+		remaining[label] += weightAt(weights, i)
+	}
+	var labels = make([]int, len(deleted))
+	var totals = make([]float64, len(*c))
+	copy(totals, remaining)
+	for i, elemt := range deleted {
+		_, labels[i], _ = c.Assign(elemt, space)
+		totals[labels[i]] += weightAt(deletedWeights, i)
+	}
+	for i, elemt := range deleted {
+		var label, weight = labels[i], weightAt(deletedWeights, i)
+		if remaining[label] > 0 {
+			retracted[label] = uncombiner.Uncombine(retracted[label], totals[label], elemt, weight)
+			totals[label] -= weight
+		}
+	}
+	for label, centroid := range retracted {
+		if remaining[label] > 0 {
+			centroids = append(centroids, centroid)
+		}
+	}
+	return
 }
 
 // TotalLoss computes loss from distances between elements and their nearest centroid
@@ -96,27 +244,57 @@ func (c *Clust) TotalLoss(elemts []Elemt, space Space, norm float64) float64 {
 	return floats.Sum(losses)
 }
 
+// WeightedTotalLoss computes loss from distances between weighted elements and their nearest centroid
+func (c *Clust) WeightedTotalLoss(elemts []Elemt, weights []float64, space Space, norm float64) float64 {
+	losses, _ := c.ReduceWeightedLoss(elemts, weights, space, norm)
+	return floats.Sum(losses)
+}
+
 // ParTotalLoss computes loss from distances between elements and their nearest centroid in parallel
 func (c *Clust) ParTotalLoss(elemts []Elemt, space Space, norm float64, degree int) float64 {
 	losses, _ := c.ParReduceLoss(elemts, space, norm, degree)
 	return floats.Sum(losses)
 }
 
+// ParWeightedTotalLoss computes loss from distances between weighted elements and their nearest centroid in parallel
+func (c *Clust) ParWeightedTotalLoss(elemts []Elemt, weights []float64, space Space, norm float64, degree int) float64 {
+	losses, _ := c.ParReduceWeightedLoss(elemts, weights, space, norm, degree)
+	return floats.Sum(losses)
+}
+
+// StableWeightedTotalLoss computes loss from distances between weighted elements and their nearest centroid in parallel.
+// The result does not depend on the degree of parallelism
+func (c *Clust) StableWeightedTotalLoss(elemts []Elemt, weights []float64, space Space, norm float64, degree int) float64 {
+	losses, _ := stableLoss(*c, elemts, weights, space, norm, degree)
+	return floats.Sum(losses)
+}
+
 // ReduceLoss computes loss and cardinality in each cluster for the given elements
 func (c *Clust) ReduceLoss(elemts []Elemt, space Space, norm float64) ([]float64, []int) {
+	return c.ReduceWeightedLoss(elemts, nil, space, norm)
+}
+
+// ReduceWeightedLoss computes weighted loss and cardinality in each cluster for the given elements.
+// All weights are 1 if weights is nil
+func (c *Clust) ReduceWeightedLoss(elemts []Elemt, weights []float64, space Space, norm float64) ([]float64, []int) {
 	var losses = make([]float64, len(*c))
 	var cards = make([]int, len(*c))
-	for _, elemt := range elemts {
+	for i, elemt := range elemts {
 		var label, min = c.nearest(elemt, space)
 		cards[label]++
-		losses[label] += math.Pow(min, norm)
+		losses[label] += weightAt(weights, i) * math.Pow(min, norm)
 	}
 	return losses, cards
 }
 
 // ParReduceLoss computes loss and cardinality in each cluster for the given elements in parallel
 func (c *Clust) ParReduceLoss(elemts []Elemt, space Space, norm float64, degree int) ([]float64, []int) {
-	return parLoss(*c, elemts, space, norm, degree)
+	return parLoss(*c, elemts, nil, space, norm, degree)
+}
+
+// ParReduceWeightedLoss computes weighted loss and cardinality in each cluster for the given elements in parallel
+func (c *Clust) ParReduceWeightedLoss(elemts []Elemt, weights []float64, space Space, norm float64, degree int) ([]float64, []int) {
+	return parLoss(*c, elemts, weights, space, norm, degree)
 }
 
 // ReduceLossForLabels computes loss and cardinality in each cluster for the given labels
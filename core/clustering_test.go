@@ -238,3 +238,114 @@ func TestClust_LossForLabels(t *testing.T) {
 		t.Error("loss error")
 	}
 }
+
+func TestClust_ReduceWeightedDBA(t *testing.T) {
+	var clust = core.Clust{[]float64{0.}, []float64{10.}}
+	var sp = euclid.Space{}
+	var elemts = []core.Elemt{[]float64{1.}, []float64{3.}, []float64{9.}, []float64{12.}}
+	var weights = []float64{3., 1., .5, 1.5}
+	var duplicated = []core.Elemt{[]float64{1.}, []float64{1.}, []float64{1.}, []float64{3.}}
+
+	var result, totals = clust.ReduceWeightedDBA(elemts, weights, sp)
+	var expected, cards = clust.ReduceDBA(duplicated, sp)
+
+	if !reflect.DeepEqual(result[0], expected[0]) || totals[0] != float64(cards[0]) {
+		t.Error("Expected", expected[0], cards[0], "got", result[0], totals[0])
+	}
+	if c := result[1].([]float64)[0]; c != 11.25 || totals[1] != 2 {
+		t.Error("Expected 11.25 with weight 2 got", c, totals[1])
+	}
+
+	var parResult, parTotals = clust.ParReduceWeightedDBA(elemts, weights, sp, 2)
+	if !reflect.DeepEqual(parResult, result) || !reflect.DeepEqual(parTotals, totals) {
+		t.Error("Expected", result, totals, "got", parResult, parTotals)
+	}
+
+	var expectedLoss = clust.WeightedTotalLoss(elemts, weights, sp, 2)
+	if _, _, loss := clust.ReduceWeightedDBALoss(elemts, weights, sp, 2); loss != expectedLoss {
+		t.Error("Expected", expectedLoss, "got", loss)
+	}
+	if _, _, loss := clust.ParReduceWeightedDBALoss(elemts, weights, sp, 2, 2); loss != expectedLoss {
+		t.Error("Expected", expectedLoss, "got", loss)
+	}
+	if _, _, loss := clust.StableReduceWeightedDBALoss(elemts, weights, sp, 2, 2); loss != expectedLoss {
+		t.Error("Expected", expectedLoss, "got", loss)
+	}
+}
+
+func TestClust_WeightedLoss(t *testing.T) {
+	var clust = core.Clust{[]float64{0.}, []float64{10.}}
+	var sp = euclid.Space{}
+	var elemts = []core.Elemt{[]float64{1.}, []float64{3.}, []float64{9.}}
+	var weights = []float64{2., 1., .5}
+
+	var losses, cards = clust.ReduceWeightedLoss(elemts, weights, sp, 2)
+	if !reflect.DeepEqual(losses, []float64{11., .5}) || !reflect.DeepEqual(cards, []int{2, 1}) {
+		t.Error("Expected [11 .5] [2 1] got", losses, cards)
+	}
+
+	if loss := clust.ParWeightedTotalLoss(elemts, weights, sp, 2, 2); loss != 11.5 {
+		t.Error("Expected 11.5 got", loss)
+	}
+	if loss := clust.WeightedTotalLoss(elemts, nil, sp, 2); loss != clust.TotalLoss(elemts, sp, 2) {
+		t.Error("Expected unit weights loss got", loss)
+	}
+}
+
+func TestWeightedCombine(t *testing.T) {
+	var sp = euclid.Space{}
+	var e1, e2 = []float64{2.}, []float64{4.}
+
+	if c := core.WeightedCombine(sp, e1, .5, e2, 1.5).([]float64); c[0] != 3.5 {
+		t.Error("Expected 3.5 got", c)
+	}
+	if c := core.WeightedCombine(intSpace{sp}, e1, .5, e2, 1.5).([]float64); c[0] != 3.5 {
+		t.Error("Expected 3.5 got", c)
+	}
+}
+
+// intSpace hides the real weights combination of an euclidean space
+type intSpace struct {
+	euclid.Space
+}
+
+func (space intSpace) CombineFloat() {}
+
+// averageSpace is an euclidean space which can not uncombine elements
+type averageSpace struct {
+	space euclid.Space
+}
+
+func (s averageSpace) Dist(e1, e2 core.Elemt) float64 { return s.space.Dist(e1, e2) }
+func (s averageSpace) Combine(e1 core.Elemt, w1 int, e2 core.Elemt, w2 int) core.Elemt {
+	return s.space.Combine(e1, w1, e2, w2)
+}
+func (s averageSpace) Copy(e core.Elemt) core.Elemt { return s.space.Copy(e) }
+func (s averageSpace) Dim(data []core.Elemt) int    { return s.space.Dim(data) }
+
+func TestClust_RetractDBA(t *testing.T) {
+	var data = []core.Elemt{[]float64{0.}, []float64{2.}, []float64{10.}, []float64{12.}, []float64{14.}}
+	var weights = []float64{1., 1., 1., 2., 1.}
+	var clust = core.Clust{[]float64{1.}, []float64{12.}}
+	var deleted = []core.Elemt{data[1], data[4]}
+	var deletedWeights = []float64{1., 1.}
+	var remaining = []core.Elemt{data[0], data[2], data[3]}
+	var remainingWeights = []float64{1., 1., 2.}
+
+	var uncombined = clust.RetractDBA(deleted, deletedWeights, remaining, remainingWeights, euclid.Space{})
+	var recomputed = clust.RetractDBA(deleted, deletedWeights, remaining, remainingWeights, averageSpace{})
+	var expected = core.Clust{[]float64{0.}, []float64{34. / 3}}
+
+	test.AssertCentroids(t, expected, uncombined)
+	test.AssertCentroids(t, expected, recomputed)
+
+	var emptied = clust.RetractDBA(data[:2], weights[:2], data[2:], weights[2:], euclid.Space{})
+	var emptiedRecomputed = clust.RetractDBA(data[:2], weights[:2], data[2:], weights[2:], averageSpace{})
+
+	test.AssertCentroids(t, core.Clust{[]float64{12.}}, emptied)
+	test.AssertCentroids(t, core.Clust{[]float64{12.}}, emptiedRecomputed)
+
+	if all := clust.RetractDBA(data, weights, nil, nil, euclid.Space{}); all == nil || len(all) != 0 {
+		t.Error("no centroid expected", all)
+	}
+}
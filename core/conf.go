@@ -21,6 +21,15 @@ type CtrlConf struct {
 	IterPerData    int            // minimal iterations per `DataPerIter` data
 	StatusNotifier StatusNotifier // algo execution notifier
 	Finishing      Finishing      // algo convergence matcher
+	HistorySize    int            // number of past models kept in the algo history. Default 0
+	Trace          bool           // record a trace of each iteration
+	TraceSize      int            // maximal number of trace records, the oldest ones are dropped. Default 0 is unlimited
+
+	StagingSize     int            // capacity of data pushed while the algorithm is alive and not yet applied. Default 2000
+	Overflow        OverflowPolicy // behavior of a push when the staging capacity is reached
+	OverflowTimeout time.Duration  // maximal push wait with the TimeoutOverflow policy
+
+	Seed uint64 // seed of every random stream, for reproducible runs. Default 0 uses time-based seeds
 }
 
 // Verify conf parameters
@@ -41,6 +50,21 @@ func (conf *CtrlConf) Verify() (err error) {
 	if err == nil && conf.Iter < 0 {
 		err = errors.New("Iter must be greater or equal than 0")
 	}
+	if err == nil && conf.HistorySize < 0 {
+		err = errors.New("HistorySize must be greater or equal than 0")
+	}
+	if err == nil && conf.TraceSize < 0 {
+		err = errors.New("TraceSize must be greater or equal than 0")
+	}
+	if err == nil && conf.StagingSize < 0 {
+		err = errors.New("StagingSize must be greater or equal than 0")
+	}
+	if err == nil && (conf.Overflow < DefaultOverflow || conf.Overflow > ErrorOverflow) {
+		err = errors.New("Overflow must be a valid overflow policy")
+	}
+	if err == nil && conf.Overflow == TimeoutOverflow && conf.OverflowTimeout <= 0 {
+		err = errors.New("OverflowTimeout must be greater than 0 with TimeoutOverflow")
+	}
 	return
 }
 
@@ -49,6 +73,15 @@ func (conf *CtrlConf) Ctrl() *CtrlConf {
 	return conf
 }
 
+// NewStaging creates a staging queue with the configured capacity and overflow policy
+func (conf *CtrlConf) NewStaging() *Staging {
+	var size = conf.StagingSize
+	if size == 0 {
+		size = pipeSize
+	}
+	return NewStaging(size, conf.Overflow, conf.OverflowTimeout)
+}
+
 // SetDefaultValues set
 func (conf *CtrlConf) SetDefaultValues() {
 }
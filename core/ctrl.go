@@ -1,55 +1,147 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"runtime"
+	"runtime/debug"
 	"time"
 )
 
 // OCCtrl online clustring controller
 type OCCtrl interface {
-	Init() error                               // initialize algo centroids with impl strategy
-	Play() error                               // play the algorithm
-	Pause() error                              // pause the algorithm (idle)
-	Wait(Finishing, time.Duration) error       // wait for finishing condition and maximal duration. By default, finishing is ready/idle/finished status, and duration is infinite
-	Stop() error                               // stop the algorithm
-	Push(Elemt) error                          // add element
-	Predict(elemt Elemt) (Elemt, int, float64) // input elemt centroid/label with distance to closest centroid
-	Batch() error                              // batch mode (stop, play, wait then stop)
-	Copy(Conf, Space) (OnlineClust, error)     // make a copy of this algo with new configuration and space
+	Init() error                                              // initialize algo centroids with impl strategy
+	Play() error                                              // play the algorithm
+	PlayContext(context.Context) error                        // play the algorithm until the context is done
+	Pause() error                                             // pause the algorithm (idle)
+	Wait(Finishing, time.Duration) error                      // wait for finishing condition and maximal duration. By default, finishing is ready/idle/finished status, and duration is infinite
+	WaitContext(context.Context, Finishing) error             // wait for finishing condition or context done. By default, finishing is ready/idle/finished status
+	Stop() error                                              // stop the algorithm
+	Step(int) (Clust, RuntimeFigures, error)                  // run exactly n iterations synchronously if ready or idle
+	Close() error                                             // stop the algorithm and release its resources. Later control calls fail
+	Push(Elemt) error                                         // add element
+	PushWeighted(Elemt, float64) error                        // add element with a weight
+	PushBatch([]Elemt) error                                  // add elements at once
+	Predict(elemt Elemt) (Elemt, int, float64)                // input elemt centroid/label with distance to closest centroid
+	PredictBatch([]Elemt, int) (Clust, []int, []float64, int) // centroids, labels, distances and model version for elements, in parallel
+	Batch() error                                             // batch mode (stop, play, wait then stop)
+	BatchContext(context.Context) error                       // batch mode interrupted when the context is done
+	Copy(Conf, Space) (OnlineClust, error)                    // make a copy of this algo with new configuration and space
+	SetConf(Conf) error                                       // change the configuration, between two iterations if running
+	SetSpace(Space) error                                     // change the space, between two iterations if running
+	Flush() error                                             // apply staged data, between two iterations if running
+	Delete(func(Elemt) bool) (int, error)                     // remove data matching a predicate and update centroids
 }
 
 // Push a new observation in the algorithm
 func (algo *Algo) Push(elemt Elemt) (err error) {
-	err = algo.impl.Push(elemt, algo)
+	if err = algo.closedError(); err == nil {
+		err = algo.impl.Push(elemt, algo)
+	}
 	if err == nil {
-		algo.modelMutex.Lock()
-		algo.pushedData++
-		algo.lastDataTime = time.Now().Unix()
-		var conf = algo.conf.Ctrl()
-		if algo.Status().Value == Ready && conf.DataPerIter > 0 && conf.DataPerIter <= algo.newData {
-			algo.newData = 0
-		} else {
-			algo.newData++
+		algo.pushed(elemt)
+	}
+	return
+}
+
+// PushWeighted pushes a new observation with a weight, which counts as weight identical observations
+func (algo *Algo) PushWeighted(elemt Elemt, weight float64) (err error) {
+	var pusher, ok = algo.impl.(WeightedPusher)
+	switch {
+	case algo.closedError() != nil:
+		err = ErrClosed
+	case !validWeight(weight):
+		err = ErrWeight
+	case !ok:
+		err = ErrNotWeighted
+	default:
+		err = pusher.PushWeighted(elemt, weight, algo)
+	}
+	if err == nil {
+		algo.pushed(elemt)
+	}
+	return
+}
+
+// PushBatch pushes several elements at once, with a single update of the algo.
+// If some elements can not be pushed, a *BatchError gives the error of each element
+func (algo *Algo) PushBatch(elemts []Elemt) (err error) {
+	if err = algo.closedError(); err != nil {
+		return
+	}
+	var errs []error
+	if pusher, ok := algo.impl.(BatchPusher); ok {
+		errs = pusher.PushBatch(elemts, algo)
+	} else {
+		for i, elemt := range elemts {
+			if elemtErr := algo.impl.Push(elemt, algo); elemtErr != nil {
+				if errs == nil {
+					errs = make([]error, len(elemts))
+				}
+				errs[i] = elemtErr
+			}
 		}
-		algo.updateRuntimeFigures()
-		algo.modelMutex.Unlock()
-		// try to play if waiting
-		algo.modelMutex.RLock()
-		defer algo.modelMutex.RUnlock()
-		if algo.newData == 0 {
-			algo.Play()
+	}
+	var pushed = elemts
+	if errs != nil {
+		var batchErr = &BatchError{Errors: errs}
+		pushed = make([]Elemt, 0, len(elemts))
+		for i, elemtErr := range errs {
+			if elemtErr == nil {
+				pushed = append(pushed, elemts[i])
+			} else {
+				batchErr.Failed++
+			}
+		}
+		if batchErr.Failed > 0 {
+			err = batchErr
 		}
 	}
+	if len(pushed) > 0 {
+		algo.pushed(pushed...)
+	}
 	return
 }
 
+// pushed updates the algo after elements have been pushed
+func (algo *Algo) pushed(elemts ...Elemt) {
+	var status = algo.Status()
+	algo.modelMutex.Lock()
+	algo.pushedData += len(elemts)
+	algo.lastDataTime = time.Now().UnixNano()
+	algo.newData += len(elemts)
+	var conf = algo.conf.Ctrl()
+	if status.Value == Ready && conf.DataPerIter > 0 && conf.DataPerIter < algo.newData {
+		algo.newData = 0
+	}
+	algo.updateRuntimeFigures()
+	algo.publishModel()
+	var play = algo.newData == 0
+	algo.modelMutex.Unlock()
+	algo.signal()
+	algo.publishPush(elemts)
+	// try to play if waiting
+	if play {
+		algo.Play()
+	}
+}
+
 // Batch executes the algorithm in batch mode
 func (algo *Algo) Batch() (err error) {
+	return algo.BatchContext(context.Background())
+}
+
+// BatchContext executes the algorithm in batch mode.
+// If the context is done before the end of the execution, the algorithm is stopped and the context error is returned
+func (algo *Algo) BatchContext(ctx context.Context) (err error) {
+	if err = algo.closedError(); err != nil {
+		return
+	}
 	algo.Stop()
-	err = algo.Play()
+	err = algo.PlayContext(ctx)
 	if err == nil {
-		err = algo.Wait(nil, 0)
-		if err == nil {
+		err = algo.WaitContext(ctx, nil)
+		if err == nil || err == ctx.Err() {
 			algo.Stop()
 		}
 	}
@@ -58,8 +150,13 @@ func (algo *Algo) Batch() (err error) {
 
 // Init initialize centroids and set status to Ready
 func (algo *Algo) Init() error {
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
 	algo.statusMutex.Lock()
 	defer algo.statusMutex.Unlock()
+	if algo.closed {
+		return ErrClosed
+	}
 	return algo.init()
 }
 
@@ -73,18 +170,20 @@ func (algo *Algo) init() (err error) {
 		algo.iterations = 0
 		algo.runtimeFigures = RuntimeFigures{}
 		algo.updateRuntimeFigures()
+		algo.publishModel()
 		algo.modelMutex.Unlock()
 		fallthrough
 	case Created:
 		if algo.status.Value == Created {
-			algo.notifChannel = make(chan OCStatus)
-			go algo.notificationLoop()
+			algo.startNotification()
 		}
+		_ = algo.flush(algo.status)
 		algo.setStatus(NewOCStatus(Initializing), false)
 		var centroids Clust
 		centroids, err = algo.impl.Init(algo)
 		algo.modelMutex.Lock()
-		algo.centroids = centroids
+		algo.updateModel(centroids)
+		algo.publishModel()
 		algo.modelMutex.Unlock()
 		if err == nil {
 			algo.setStatus(NewOCStatus(Ready), false)
@@ -99,44 +198,106 @@ func (algo *Algo) init() (err error) {
 
 // Play the algorithm in online mode
 func (algo *Algo) Play() (err error) {
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
+	return algo.play()
+}
+
+func (algo *Algo) play() (err error) {
 	algo.statusMutex.Lock()
+	if algo.closed {
+		algo.statusMutex.Unlock()
+		return ErrClosed
+	}
 	switch algo.status.Value {
 	case Idle:
+		var done = algo.runDone
 		algo.statusMutex.Unlock()
-		algo.sendStatus(NewOCStatus(Running))
+		algo.sendStatus(NewOCStatus(Running), done)
 	case Finished:
 		fallthrough
 	case Created:
 		err = algo.init()
 		if err != nil && err != ErrAlreadyCreated {
+			algo.statusMutex.Unlock()
 			return
 		}
 		err = nil
 		fallthrough
 	case Ready:
-		go algo.run()
+		var done = make(chan struct{})
+		algo.runDone = done
+		go algo.run(done)
 		algo.statusMutex.Unlock()
-		algo.sendStatus(NewOCStatus(Running))
-		if algo.timeout != nil {
-			algo.timeout.Disable()
-		}
-		var interruptionTimeout = algo.Conf().Ctrl().Timeout
-		if interruptionTimeout > 0 {
-			algo.timeout = InterruptionTimeout(interruptionTimeout, algo.interrupt)
-		}
+		algo.sendStatus(NewOCStatus(Running), done)
+		algo.startTimeout()
 	case Running:
 		algo.statusMutex.Unlock()
 		err = ErrRunning
+	default:
+		algo.statusMutex.Unlock()
+		err = ErrInitializing
 	}
 	return
 }
 
+// startTimeout replaces the current interruption timeout with the configured one.
+// The timeout is disabled when the current run exits
+func (algo *Algo) startTimeout() {
+	if algo.timeout != nil {
+		algo.timeout.Disable()
+	}
+	var interruptionTimeout = algo.Conf().Ctrl().Timeout
+	if interruptionTimeout > 0 {
+		var done = algo.runDone
+		algo.timeout = runTimeout(interruptionTimeout, done, func(interruption error) error {
+			return algo.timeoutInterrupt(interruption, done)
+		})
+	}
+}
+
+// PlayContext plays the algorithm in online mode.
+// The algorithm is stopped with the context error as soon as the context is done
+func (algo *Algo) PlayContext(ctx context.Context) (err error) {
+	err = ctx.Err()
+	if err == nil {
+		algo.ctrlMutex.Lock()
+		defer algo.ctrlMutex.Unlock()
+		err = algo.play()
+		if err == nil && ctx.Done() != nil {
+			go algo.watchContext(ctx, algo.runDone)
+		}
+	}
+	return
+}
+
+// watchContext interrupts the run identified by done when the context is done
+func (algo *Algo) watchContext(ctx context.Context, done <-chan struct{}) {
+	select {
+	case <-ctx.Done():
+		algo.ctrlMutex.Lock()
+		defer algo.ctrlMutex.Unlock()
+		select {
+		case <-done: // the run exited meanwhile
+		default:
+			algo.interrupt(ctx.Err())
+		}
+	case <-done:
+	}
+}
+
 // Pause the algorithm and set status to idle
 func (algo *Algo) Pause() (err error) {
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
 	algo.statusMutex.Lock()
-	if algo.status.Value == Running {
+	if algo.closed {
+		err = ErrClosed
 		algo.statusMutex.Unlock()
-		if !algo.sendStatus(NewOCStatus(Idle)) {
+	} else if algo.status.Value == Running {
+		var done = algo.runDone
+		algo.statusMutex.Unlock()
+		if !algo.sendStatus(NewOCStatus(Idle), done) {
 			err = ErrNotRunning
 		}
 	} else {
@@ -154,12 +315,27 @@ func (algo *Algo) CanNeverFinish(finishing Finishing, timeout time.Duration) boo
 
 // Wait for online finishing predicate
 func (algo *Algo) Wait(finishing Finishing, timeout time.Duration) (err error) {
+	var ctx, cancel = timeoutContext(timeout)
+	defer cancel()
+	err = algo.WaitContext(ctx, finishing)
+	if err != nil && err == ctx.Err() {
+		err = ErrTimeout
+	}
+	return
+}
+
+// WaitContext for online finishing predicate or context done.
+// Waiting is driven by the algorithm status and model changes
+func (algo *Algo) WaitContext(ctx context.Context, finishing Finishing) (err error) {
+	if err = algo.closedError(); err != nil {
+		return
+	}
 	switch algo.Status().Value {
 	case Running:
-		if algo.CanNeverFinish(finishing, timeout) {
+		if ctx.Done() == nil && algo.CanNeverFinish(finishing, 0) {
 			err = ErrNeverFinish
 		} else {
-			err = WaitTimeout(finishing, timeout, algo)
+			err = WaitContext(ctx, finishing, algo)
 		}
 	case Idle:
 		err = ErrIdle
@@ -174,112 +350,149 @@ func (algo *Algo) Wait(finishing Finishing, timeout time.Duration) (err error) {
 
 // interrupt the algorithm
 func (algo *Algo) interrupt(interruption error) (err error) {
-	algo.statusMutex.Lock()
-	switch algo.status.Value {
-	case Ready:
-		algo.setStatus(NewOCStatusError(interruption), false)
-		algo.statusMutex.Unlock()
-	case Idle:
-		fallthrough
-	case Running:
-		algo.statusMutex.Unlock()
-		algo.sendStatus(NewOCStatusError(interruption))
-		err = algo.status.Error
-	default:
-		algo.statusMutex.Unlock()
-		err = ErrNotAlive
+	var interrupted bool
+	for err == nil && !interrupted {
+		algo.statusMutex.Lock()
+		switch algo.status.Value {
+		case Ready:
+			switch {
+			case interruption != nil && algo.status.Terminated(): // the last run ended before the interruption
+			case interruption == nil && algo.status.Terminated(): // keep the reason of the last run
+				_ = algo.flush(algo.status)
+				var status = NewOCStatusError(nil)
+				status.Reason = algo.status.Reason
+				algo.setStatus(status, false)
+			default:
+				_ = algo.flush(algo.status)
+				algo.setStatus(NewOCStatusError(interruption), false)
+			}
+			algo.statusMutex.Unlock()
+			interrupted = true
+		case Idle:
+			fallthrough
+		case Running:
+			var done = algo.runDone
+			algo.statusMutex.Unlock()
+			// retry if the run go routine exited before receiving the interruption
+			if interrupted = algo.sendStatus(NewOCStatusError(interruption), done); interrupted {
+				err = algo.Status().Error
+			}
+		default:
+			algo.statusMutex.Unlock()
+			err = ErrNotAlive
+		}
 	}
 	return
 }
 
 // Stop the algorithm
 func (algo *Algo) Stop() (err error) {
-	return algo.interrupt(nil)
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
+	if err = algo.closedError(); err == nil {
+		err = algo.interrupt(nil)
+	}
+	return
 }
 
-// Predict the cluster for a new observation
+// timeoutInterrupt interrupts the run identified by done with a timeout error, unless the run exited meanwhile
+func (algo *Algo) timeoutInterrupt(interruption error, done <-chan struct{}) (err error) {
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
+	if err = algo.closedError(); err != nil {
+		return
+	}
+	select {
+	case <-done:
+	default:
+		err = algo.interrupt(interruption)
+	}
+	return
+}
+
+// Predict the cluster for a new observation with the last published model, without locking
 func (algo *Algo) Predict(elemt Elemt) (pred Elemt, label int, dist float64) {
-	var clust = algo.Centroids()
-	pred, label, dist = clust.Assign(elemt, algo.space)
+	var view = algo.loadView()
+	pred, label, dist = view.Centroids.Assign(elemt, view.Space)
 	return
 }
 
-func (algo *Algo) recover(start time.Time) {
-	algo.statusMutex.Lock()
-	defer algo.statusMutex.Unlock()
+// PredictBatch predicts the clusters of elements in parallel with the given degree, all with the same centroids.
+// Returns the centroids, labels and distances to the centroids, and the version of the model used
+func (algo *Algo) PredictBatch(elemts []Elemt, degree int) (centroids Clust, labels []int, dists []float64, version int) {
+	var view = algo.loadView()
+	centroids = make(Clust, len(view.Centroids))
+	copy(centroids, view.Centroids)
+	var space = view.Space
+	version = view.Version
+	if degree < 1 {
+		degree = runtime.NumCPU()
+	}
+	labels, dists = centroids.ParMapLabel(elemts, space, degree)
+	return
+}
+
+func (algo *Algo) recover(start *time.Time, done chan struct{}, status *OCStatus) {
 	var recovery = recover()
 	if recovery != nil {
-		var err = fmt.Errorf("%v", recovery)
-		algo.setStatus(NewOCStatusError(err), false)
+		var err = algo.iterationError(panicError(recovery), *status, debug.Stack())
+		algo.saveDuration(start)
+		algo.setStatus(NewOCStatusError(err), true)
 	}
-	// wait a few between client time processing between releasing statusMutex and sendStatus
-	time.Sleep(100 * time.Millisecond)
-	// update algo runtime figures
+	// release go routines waiting for acknowledgement
+	close(done)
+}
+
+// saveDuration adds the running duration since start to the algo duration and publishes it.
+// start is moved to now so that the same duration is not counted twice
+func (algo *Algo) saveDuration(start *time.Time) {
+	var now = time.Now()
 	algo.modelMutex.Lock()
-	// algo.newData = int(math.Max(0, float64(algo.newData-newData)))
-	var duration = time.Now().Sub(start)
-	algo.duration += duration
+	algo.duration += now.Sub(*start)
 	algo.runtimeFigures[Duration] = float64(algo.duration)
+	algo.updateRuntimeFigures()
+	algo.publishModel()
 	algo.modelMutex.Unlock()
-	// clean up channels
-	select {
-	// get client status if client quiered to stop or timeout interruption
-	case status := <-algo.statusChannel:
-		if recovery == nil {
-			algo.setStatus(status, false)
-		}
-	default:
-	}
-	// free client ack channel
-	select { // close ack channel
-	case <-algo.ackChannel:
-	default:
-		close(algo.ackChannel)
-	}
+	*start = now
 }
 
 // Initialize the algorithm, if success run it synchronously otherwise return an error
-func (algo *Algo) run() {
-	algo.ackChannel = make(chan bool)
-
+func (algo *Algo) run(done chan struct{}) {
 	var err error
-	var conf = algo.conf.Ctrl()
-	var centroids = algo.centroids
+	var centroids Clust
 	var runtimeFigures RuntimeFigures
-	var iterFreq time.Duration
-	if conf.IterFreq > 0 {
-		iterFreq = time.Duration(float64(time.Second) / conf.IterFreq)
-	}
+	var iterFreq, finishing = iterationSettings(algo.Conf().Ctrl())
 	var lastIterationTime = time.Now()
-
-	// var newData int
+	var reason Reason
+	resetFinishing(finishing.exhaustion)
+	resetFinishing(finishing.convergence)
 
 	var start = time.Now()
 	var duration time.Duration
 
-	var finishing Finishing = NewIterFinishing(conf.Iter, conf.IterPerData)
-	if conf.Finishing != nil {
-		finishing = NewOrFinishing(finishing, conf.Finishing)
-	}
-
-	defer algo.recover(start)
+	var status OCStatus
+	defer algo.recover(&start, done, &status)
 
-	algo.receiveStatus()
+	status = algo.receiveStatus()
 
-	for err == nil && algo.status.Value == Running && !IsFinished(finishing, algo) {
+	for err == nil && status.Value == Running {
+		if reason = finishing.reason(algo); reason != NoReason {
+			break
+		}
 		select { // check for algo status update
-		case status := <-algo.statusChannel:
-			algo.setStatus(status, true)
+		case next := <-algo.statusChannel:
+			status = algo.leaveStatus(status, next, &start)
 			if status.Value == Idle {
-				algo.ackChannel <- true
-				algo.receiveStatus()
+				status = algo.leaveStatus(status, <-algo.statusChannel, &start)
 			}
+		case task := <-algo.taskChannel: // execute task between two iterations
+			task.result <- task.process()
+			iterFreq, finishing = iterationSettings(algo.Conf().Ctrl())
 		default:
 			// run implementation
-			// newData = algo.newData
 			centroids, runtimeFigures, err = algo.impl.Iterate(
 				NewSimpleOCModel(
-					algo.conf, algo.space, algo.status, algo.runtimeFigures, algo.centroids,
+					algo.Conf(), algo.Space(), status, algo.RuntimeFigures(), algo.Centroids(),
 				),
 			)
 			duration = time.Now().Sub(start)
@@ -291,6 +504,14 @@ func (algo *Algo) run() {
 						centroids, runtimeFigures, duration,
 					)
 					algo.modelMutex.Unlock()
+					algo.signal()
+					algo.publishIteration(status)
+				} else { // keep the running duration up to date without iteration
+					algo.modelMutex.Lock()
+					algo.runtimeFigures[Duration] = float64(algo.duration + duration)
+					algo.publishModel()
+					algo.modelMutex.Unlock()
+					algo.signal() // wake up time-based finishings
 				}
 				// temporize iteration
 				if iterFreq > 0 { // with iteration freqency
@@ -301,77 +522,186 @@ func (algo *Algo) run() {
 			}
 		}
 	}
+	if status.Value == Running { // apply staged data before leaving the running status
+		_ = algo.flush(status)
+	}
+	algo.saveDuration(&start) // before the final status, thus figures are up to date when waiters are released
 	if err == nil {
-		if algo.status.Value == Running {
-			algo.setStatus(NewOCStatus(Ready), true)
+		if status.Value == Running {
+			algo.setStatus(NewOCStatusReason(Ready, reason), true)
 		}
 	} else {
-		algo.setStatus(NewOCStatusError(err), true)
+		algo.setStatus(NewOCStatusError(algo.iterationError(err, status, nil)), true)
+	}
+}
+
+// iterationError wraps an iteration error or panic with the iteration context
+func (algo *Algo) iterationError(err error, status OCStatus, stack []byte) error {
+	algo.modelMutex.RLock()
+	var iteration = algo.iterations + 1
+	algo.modelMutex.RUnlock()
+	return &IterationError{
+		Iteration: iteration,
+		Status:    status,
+		Impl:      fmt.Sprintf("%T", algo.impl),
+		Stack:     stack,
+		Err:       err,
+	}
+}
+
+// runFinishing is the finishing condition of a run, which distinguishes convergence from exhausted iterations
+type runFinishing struct {
+	exhaustion  Finishing // iterations given by Iter and IterPerData
+	convergence Finishing // configured finishing
+}
+
+// reason returns the reason why the run is finished, or NoReason if it is not
+func (finishing runFinishing) reason(ocm OCModel) (reason Reason) {
+	if IsFinished(finishing.convergence, ocm) {
+		reason = Converged
+	} else if IsFinished(finishing.exhaustion, ocm) {
+		reason = Exhausted
+	}
+	return
+}
+
+// panicError returns the error of a recovered panic
+func panicError(recovery interface{}) (err error) {
+	var ok bool
+	if err, ok = recovery.(error); !ok {
+		err = fmt.Errorf("%v", recovery)
+	}
+	return
+}
+
+// iterationSettings returns the iteration period and the finishing condition of a configuration
+func iterationSettings(conf *CtrlConf) (iterFreq time.Duration, finishing runFinishing) {
+	if conf.IterFreq > 0 {
+		iterFreq = time.Duration(float64(time.Second) / conf.IterFreq)
 	}
+	finishing = runFinishing{
+		exhaustion:  NewIterFinishing(conf.Iter, conf.IterPerData),
+		convergence: conf.Finishing,
+	}
+	return
 }
 
 func (algo *Algo) updateRuntimeFigures() {
 	algo.runtimeFigures[Iterations] = float64(algo.iterations)
 	algo.runtimeFigures[PushedData] = float64(algo.pushedData)
-	algo.runtimeFigures[LastDataTime] = float64(algo.lastDataTime)
+	algo.runtimeFigures[LastDataTime] = float64(algo.lastDataTime) / float64(time.Second)
+	if counter, ok := algo.impl.(StagingCounter); ok {
+		var staging = counter.Staging()
+		algo.runtimeFigures[StagedData] = float64(staging.Len())
+		algo.runtimeFigures[DroppedData] = float64(staging.Dropped())
+	}
+	if counter, ok := algo.impl.(AgeCounter); ok {
+		if oldest, newest, timed := counter.Ages(); timed {
+			algo.runtimeFigures[OldestAge] = oldest.Seconds()
+			algo.runtimeFigures[NewestAge] = newest.Seconds()
+		}
+	}
+	if ctrl := algo.conf.Ctrl(); ctrl.Reproducible() {
+		algo.runtimeFigures[Seed] = float64(ctrl.Seed)
+	}
 }
 
 func (algo *Algo) saveIterContext(centroids Clust, runtimeFigures RuntimeFigures, duration time.Duration) {
 	if runtimeFigures == nil {
 		runtimeFigures = RuntimeFigures{}
 	}
+	algo.traceIteration(centroids, runtimeFigures)
 	runtimeFigures[Duration] = float64(algo.duration + duration)
-	algo.centroids = centroids
 	algo.runtimeFigures = runtimeFigures
 	algo.updateRuntimeFigures()
+	algo.updateModel(centroids)
+	algo.publishModel()
 }
 
-/*
-// SetConf algo configuration and space
+// SetConf changes the algorithm configuration.
+// If the algorithm is running, the configuration is applied between two iterations.
+// Impl settings are taken into account if the impl is Reconfigurable. A new timeout is counted from the reconfiguration
 func (algo *Algo) SetConf(conf Conf) (err error) {
-	algo.ctrlMutex.Lock()
-	defer algo.ctrlMutex.Unlock()
-	if algo.status.Value == Running {
-		err = ErrRunning
-	} else {
-		err = PrepareConf(conf)
-		if err == nil {
-			impl, err := algo.impl.Copy(algo)
-			if err == nil {
-				algo.modelMutex.Lock()
-				algo.impl = impl
-				algo.conf = conf
-				algo.modelMutex.Unlock()
-			} else {
-				algo.setStatus(NewOCStatusError(err), true)
-			}
+	err = PrepareConf(conf)
+	if err == nil {
+		algo.ctrlMutex.Lock()
+		defer algo.ctrlMutex.Unlock()
+		if err = algo.closedError(); err != nil {
+			return
+		}
+		var timeout = algo.Conf().Ctrl().Timeout
+		var space = algo.Space()
+		err = algo.execute(func() error { return algo.reconfigure(conf, space) })
+		if err == nil && conf.Ctrl().Timeout != timeout && algo.Status().Running() {
+			algo.startTimeout()
 		}
 	}
 	return
 }
 
-// SetSpace algo configuration and space
+// SetSpace changes the algorithm space.
+// If the algorithm is running, the space is applied between two iterations
 func (algo *Algo) SetSpace(space Space) (err error) {
 	algo.ctrlMutex.Lock()
 	defer algo.ctrlMutex.Unlock()
-	if algo.status.Value == Running {
-		err = ErrRunning
-	} else {
-		impl, err := algo.impl.Copy(algo)
-		if err == nil {
-			algo.modelMutex.Lock()
-			algo.impl = impl
-			algo.space = space
-			algo.modelMutex.Unlock()
-		} else {
-			algo.setStatus(NewOCStatusError(err), true)
+	if err = algo.closedError(); err != nil {
+		return
+	}
+	var conf = algo.Conf()
+	return algo.execute(func() error { return algo.reconfigure(conf, space) })
+}
+
+// task executed while the impl is not iterating
+type task struct {
+	process func() error
+	result  chan error
+}
+
+// execute a task between two iterations if running, otherwise immediately.
+// The controller mutex must be locked by the caller
+func (algo *Algo) execute(process func() error) (err error) {
+	algo.statusMutex.RLock()
+	var status, done = algo.status.Value, algo.runDone
+	algo.statusMutex.RUnlock()
+	if status == Running {
+		var result = make(chan error, 1)
+		select {
+		case algo.taskChannel <- task{process: process, result: result}:
+			err = <-result
+		case <-done: // the run go routine exited meanwhile
+			err = process()
 		}
+	} else {
+		err = process()
 	}
 	return
-}*/
+}
+
+// reconfigure the impl and the model with a new configuration and space, keeping current centroids
+func (algo *Algo) reconfigure(conf Conf, space Space) (err error) {
+	var centroids = algo.Centroids()
+	if impl, ok := algo.impl.(Reconfigurable); ok {
+		centroids, err = impl.Reconfigure(
+			NewSimpleOCModel(conf, space, algo.Status(), algo.RuntimeFigures(), centroids),
+		)
+	}
+	if err == nil {
+		algo.modelMutex.Lock()
+		algo.conf = conf
+		algo.space = space
+		algo.updateModel(centroids)
+		algo.publishModel()
+		algo.modelMutex.Unlock()
+		algo.signal()
+	}
+	return
+}
 
 // Copy make a copy of this algo with new conf and space
 func (algo *Algo) Copy(conf Conf, space Space) (oc OnlineClust, err error) {
+	if err = algo.closedError(); err != nil {
+		return
+	}
 	impl, err := algo.impl.Copy(algo)
 	if err == nil {
 		oc = NewAlgo(conf, impl, space)
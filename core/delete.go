@@ -0,0 +1,41 @@
+package core
+
+// Delete removes the data matching the predicate from the impl, between two iterations if running.
+// Centroids are updated without the removed data and the model version is incremented.
+// Returns the number of removed data
+func (algo *Algo) Delete(predicate func(Elemt) bool) (count int, err error) {
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
+	if err = algo.closedError(); err != nil {
+		return
+	}
+	var deleter, ok = algo.impl.(Deleter)
+	if !ok {
+		return 0, ErrNotDeletable
+	}
+	err = algo.execute(func() (err error) {
+		var model = NewSimpleOCModel(algo.Conf(), algo.Space(), algo.Status(), algo.RuntimeFigures(), algo.Centroids())
+		var centroids Clust
+		centroids, count, err = deleter.Delete(predicate, model)
+		if err == nil && count > 0 {
+			algo.deleted(centroids)
+		}
+		return
+	})
+	return
+}
+
+// deleted updates the model after data have been removed. The version is incremented even if centroids did not change
+func (algo *Algo) deleted(centroids Clust) {
+	algo.modelMutex.Lock()
+	var version = algo.version
+	algo.updateModel(centroids)
+	if algo.version == version {
+		algo.version++
+		algo.record()
+	}
+	algo.updateRuntimeFigures()
+	algo.publishModel()
+	algo.modelMutex.Unlock()
+	algo.signal()
+}
@@ -1,6 +1,9 @@
 package core
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrNotRunning raised while algorithm status equals Created, Ready or Failed
 var ErrNotRunning = errors.New("Algorithm is not running")
@@ -43,3 +46,86 @@ var ErrNotIterate = errors.New("algorithm can not iterate. Check iterations and
 
 // ErrNotAlive raised when algo is not alive
 var ErrNotAlive = errors.New("algorithm is not alive")
+
+// ErrSnapshotVersion raised when a snapshot has an unsupported format version
+var ErrSnapshotVersion = errors.New("unsupported snapshot version")
+
+// ErrNotSnapshotable raised when a snapshot contains an impl state and the impl can not restore it
+var ErrNotSnapshotable = errors.New("impl is not snapshotable")
+
+// ErrUnknownSpace raised when a space name has not been registered
+var ErrUnknownSpace = errors.New("unknown space")
+
+// ErrNotIdentifiedSpace raised when exporting a model with a space which is not identified
+var ErrNotIdentifiedSpace = errors.New("space is not identified")
+
+// ErrModelVersion raised when a model file has an unsupported format version
+var ErrModelVersion = errors.New("unsupported model version")
+
+// ErrElemtType raised when a space receives an element of an unexpected type
+var ErrElemtType = errors.New("unexpected element type")
+
+// ErrElemtEncoding raised when decoding invalid element bytes
+var ErrElemtEncoding = errors.New("invalid element encoding")
+
+// ErrUnknownVersion raised when a model version is neither the current one nor in the history
+var ErrUnknownVersion = errors.New("unknown model version")
+
+// ErrWeight raised when pushing an element with a weight which is not a positive number
+var ErrWeight = errors.New("weight must be a positive number")
+
+// ErrNotWeighted raised when pushing a weighted element to an impl which does not accept weights
+var ErrNotWeighted = errors.New("impl does not accept weighted elements")
+
+// ErrBufferFull raised when pushing an element while the staging capacity is reached
+var ErrBufferFull = errors.New("buffer is full")
+
+// ErrNotDeletable raised when deleting data from an impl which can not remove data
+var ErrNotDeletable = errors.New("impl can not delete data")
+
+// ErrClosed raised when calling a closed algorithm
+var ErrClosed = errors.New("algorithm is closed")
+
+// BatchError reports the elements of a batch which could not be pushed
+type BatchError struct {
+	Errors []error // error of each element, nil for pushed elements
+	Failed int     // number of elements which could not be pushed
+}
+
+// Error message with the number of failed elements and the first error
+func (err *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d elements not pushed: %v", err.Failed, len(err.Errors), err.Unwrap())
+}
+
+// Unwrap returns the first element error
+func (err *BatchError) Unwrap() error {
+	for _, elemtErr := range err.Errors {
+		if elemtErr != nil {
+			return elemtErr
+		}
+	}
+	return nil
+}
+
+// IterationError wraps an error raised by an impl iteration, or a panic, with its execution context
+type IterationError struct {
+	Iteration int      // number of the failed iteration, starting from 1
+	Status    OCStatus // algo status during the iteration
+	Impl      string   // impl type name
+	Stack     []byte   // go routine stack if the iteration panicked
+	Err       error    // cause
+}
+
+// Error message with the iteration context
+func (err *IterationError) Error() string {
+	var kind = "failed"
+	if err.Stack != nil {
+		kind = "panicked"
+	}
+	return fmt.Sprintf("iteration %d of %s %s while %v: %v", err.Iteration, err.Impl, kind, err.Status.Value, err.Err)
+}
+
+// Unwrap returns the cause
+func (err *IterationError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of an algorithm event. Types can be combined as a bit mask
+type EventType int
+
+// EventType const values
+const (
+	StatusEvent    EventType = 1 << iota // status change
+	IterationEvent                       // iteration executed
+	PushEvent                            // element pushed
+)
+
+// AllEvents matches every event type
+const AllEvents = StatusEvent | IterationEvent | PushEvent
+
+// EventPolicy defines the behavior of the publisher when a subscriber channel is full
+type EventPolicy int
+
+// EventPolicy const values
+const (
+	DropEvents  EventPolicy = iota // events are dropped for slow subscribers (default)
+	BlockEvents                    // publisher waits for slow subscribers
+)
+
+// Event describes an algorithm change
+type Event struct {
+	Type           EventType
+	Time           time.Time
+	Status         OCStatus       // algo status at the event time
+	Iterations     int            // number of iterations at the event time
+	RuntimeFigures RuntimeFigures // copy of the algo runtime figures for iteration events
+	Centroids      Clust          // snapshot of the centroids for iteration events
+	Elemt          Elemt          // pushed element for push events
+}
+
+// EventFilter configures a subscription
+type EventFilter struct {
+	Types  EventType   // subscribed event types. All types if 0
+	Size   int         // channel capacity. Default is 100
+	Policy EventPolicy // slow subscriber policy
+}
+
+// default subscription channel capacity
+const eventBufferSize = 100
+
+// subscription of a consumer to algorithm events
+type subscription struct {
+	filter EventFilter
+	events chan Event
+	done   chan struct{} // closed when unsubscribing
+	once   sync.Once
+	mutex  sync.RWMutex
+	closed bool
+}
+
+// Subscribe returns a channel that receives the algorithm events matching the filter.
+// The channel is closed by Unsubscribe or Close
+func (algo *Algo) Subscribe(filter EventFilter) <-chan Event {
+	if filter.Types == 0 {
+		filter.Types = AllEvents
+	}
+	if filter.Size <= 0 {
+		filter.Size = eventBufferSize
+	}
+	var sub = &subscription{
+		filter: filter,
+		events: make(chan Event, filter.Size),
+		done:   make(chan struct{}),
+	}
+	algo.subscribeMutex.Lock()
+	defer algo.subscribeMutex.Unlock()
+	if algo.unsubscribed { // closed algorithms do not publish events anymore
+		sub.close()
+		return sub.events
+	}
+	if algo.subscriptions == nil {
+		algo.subscriptions = map[<-chan Event]*subscription{}
+	}
+	algo.subscriptions[sub.events] = sub
+	return sub.events
+}
+
+// Unsubscribe stops sending events to the given channel and closes it
+func (algo *Algo) Unsubscribe(events <-chan Event) {
+	algo.subscribeMutex.Lock()
+	var sub, ok = algo.subscriptions[events]
+	delete(algo.subscriptions, events)
+	algo.subscribeMutex.Unlock()
+	if ok {
+		sub.close()
+	}
+}
+
+// publish an event to all matching subscribers
+func (algo *Algo) publish(event Event) {
+	algo.subscribeMutex.RLock()
+	var subs = make([]*subscription, 0, len(algo.subscriptions))
+	for _, sub := range algo.subscriptions {
+		if sub.filter.Types&event.Type != 0 {
+			subs = append(subs, sub)
+		}
+	}
+	algo.subscribeMutex.RUnlock()
+	for _, sub := range subs {
+		sub.send(event)
+	}
+}
+
+// subscribed returns true if at least one subscriber listens to the given event type
+func (algo *Algo) subscribed(eventType EventType) (ok bool) {
+	algo.subscribeMutex.RLock()
+	defer algo.subscribeMutex.RUnlock()
+	for _, sub := range algo.subscriptions {
+		if ok = sub.filter.Types&eventType != 0; ok {
+			break
+		}
+	}
+	return
+}
+
+// send an event according to the subscription policy
+func (sub *subscription) send(event Event) {
+	sub.mutex.RLock()
+	defer sub.mutex.RUnlock()
+	if sub.closed {
+		return
+	}
+	if sub.filter.Policy == BlockEvents {
+		select {
+		case sub.events <- event:
+		case <-sub.done:
+		}
+	} else {
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+// close the subscription channel once all pending sends are released
+func (sub *subscription) close() {
+	sub.once.Do(func() {
+		close(sub.done)
+		sub.mutex.Lock()
+		defer sub.mutex.Unlock()
+		sub.closed = true
+		close(sub.events)
+	})
+}
+
+// newEvent builds an event with the current algo status
+func (algo *Algo) newEvent(eventType EventType, status OCStatus) Event {
+	algo.modelMutex.RLock()
+	defer algo.modelMutex.RUnlock()
+	return Event{
+		Type:       eventType,
+		Time:       time.Now(),
+		Status:     status,
+		Iterations: algo.iterations,
+	}
+}
+
+// publishIteration publishes an iteration event with a snapshot of the model
+func (algo *Algo) publishIteration(status OCStatus) {
+	if algo.subscribed(IterationEvent) {
+		var event = algo.newEvent(IterationEvent, status)
+		algo.modelMutex.RLock()
+		event.Centroids = make(Clust, len(algo.centroids))
+		copy(event.Centroids, algo.centroids)
+		event.RuntimeFigures = make(RuntimeFigures, len(algo.runtimeFigures))
+		for key, value := range algo.runtimeFigures {
+			event.RuntimeFigures[key] = value
+		}
+		algo.modelMutex.RUnlock()
+		algo.publish(event)
+	}
+}
+
+// publishPush publishes a push event for each pushed element
+func (algo *Algo) publishPush(elemts []Elemt) {
+	if algo.subscribed(PushEvent) {
+		var event = algo.newEvent(PushEvent, algo.Status())
+		for _, elemt := range elemts {
+			event.Elemt = elemt
+			algo.publish(event)
+		}
+	}
+}
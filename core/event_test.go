@@ -0,0 +1,106 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+func Test_Subscribe(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 3)
+
+	var events = algo.Subscribe(core.EventFilter{Policy: core.BlockEvents})
+
+	_ = algo.Push([]float64{1.})
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	algo.Unsubscribe(events)
+
+	var iterations, pushes int
+	var statuses []core.ClustStatus
+	for event := range events {
+		switch event.Type {
+		case core.StatusEvent:
+			statuses = append(statuses, event.Status.Value)
+		case core.IterationEvent:
+			iterations++
+			if event.Iterations != iterations {
+				t.Error("wrong iteration number", event.Iterations, iterations)
+			}
+			if len(event.Centroids) != 4 {
+				t.Error("centroids snapshot expected", event.Centroids)
+			}
+			if event.RuntimeFigures[core.Iterations] != float64(iterations) {
+				t.Error("runtime figures expected", event.RuntimeFigures)
+			}
+		case core.PushEvent:
+			pushes++
+		}
+	}
+
+	if pushes != 1 {
+		t.Error("1 push event expected", pushes)
+	}
+	if iterations != 5 {
+		t.Error("5 iteration events expected", iterations)
+	}
+	var expected = []core.ClustStatus{core.Initializing, core.Ready, core.Running, core.Ready, core.Finished}
+	if len(statuses) != len(expected) {
+		t.Error("wrong statuses", statuses)
+	} else {
+		for i := range expected {
+			if statuses[i] != expected[i] {
+				t.Errorf("wrong status: %v. Expected %v", statuses[i], expected[i])
+			}
+		}
+	}
+}
+
+func Test_SubscribeFilterAndDrop(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 20}, 3)
+
+	var events = algo.Subscribe(core.EventFilter{Types: core.IterationEvent, Size: 1})
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	algo.Unsubscribe(events)
+
+	var count int
+	for event := range events {
+		if event.Type != core.IterationEvent {
+			t.Error("iteration event expected", event.Type)
+		}
+		count++
+	}
+
+	if count != 1 {
+		t.Error("1 event expected", count)
+	}
+}
+
+func Test_Unsubscribe(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 1}, 3)
+
+	var events = algo.Subscribe(core.EventFilter{})
+	algo.Unsubscribe(events)
+	algo.Unsubscribe(events)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if _, ok := <-events; ok {
+		t.Error("closed channel expected")
+	}
+}
@@ -10,6 +10,8 @@ const (
 	PushedData = "pushedData"
 	// Duration is algo duration
 	Duration = "duration"
-	// LastDataTime is the last pushed data time
+	// LastDataTime is the last pushed data time, in seconds since the Unix epoch
 	LastDataTime = "lastDataTime"
+	// Loss is the clustering loss given by respective impl
+	Loss = "loss"
 )
@@ -1,5 +1,11 @@
 package core
 
+import (
+	"math"
+	"sync"
+	"time"
+)
+
 // Finishing defines
 type Finishing interface {
 	IsFinished(OCModel) bool
@@ -80,8 +86,9 @@ func NewIterFinishing(iter int, iterPerData int) IterFinishing {
 
 // StatusFinishing compare OCModel status
 type StatusFinishing struct {
-	Status []ClustStatus // Specific status finish condition
-	Error  bool          // if true and ocm failed, finish
+	Status  []ClustStatus // Specific status finish condition
+	Error   bool          // if true and ocm failed, finish
+	Reasons []Reason      // Specific terminal reason finish condition
 }
 
 // IsFinished StatusFinishing finish condition
@@ -95,6 +102,9 @@ func (sf StatusFinishing) IsFinished(ocm OCModel) (cond bool) {
 			}
 		}
 	}
+	for i := 0; !cond && i < len(sf.Reasons); i++ {
+		cond = sf.Reasons[i] == status.Reason
+	}
 	return
 }
 
@@ -105,3 +115,186 @@ func NewStatusFinishing(error bool, status ...ClustStatus) StatusFinishing {
 		Error:  error,
 	}
 }
+
+// NewReasonFinishing returns a status finishing matching terminal reasons
+func NewReasonFinishing(reasons ...Reason) StatusFinishing {
+	return StatusFinishing{
+		Reasons: reasons,
+	}
+}
+
+// ResettableFinishing is implemented by finishings with a state.
+// The state is reset each time the algorithm starts running
+type ResettableFinishing interface {
+	Finishing
+	Reset()
+}
+
+// resetFinishing resets a finishing and the finishings it is composed of
+func resetFinishing(finishing Finishing) {
+	switch f := finishing.(type) {
+	case AndFinishing:
+		for _, finishing := range f.Finishings {
+			resetFinishing(finishing)
+		}
+	case OrFinishing:
+		for _, finishing := range f.Finishings {
+			resetFinishing(finishing)
+		}
+	case ResettableFinishing:
+		f.Reset()
+	}
+}
+
+// CentroidShiftFinishing finishes when the maximal distance between the centroids of two consecutive iterations
+// is lower than Epsilon. A finishing instance must not be shared by several algorithms
+type CentroidShiftFinishing struct {
+	Epsilon    float64
+	mutex      sync.Mutex
+	iterations float64 // iterations of the last observed centroids
+	centroids  Clust   // last observed centroids
+	finished   bool
+}
+
+// NewCentroidShiftFinishing returns new instance
+func NewCentroidShiftFinishing(epsilon float64) *CentroidShiftFinishing {
+	return &CentroidShiftFinishing{Epsilon: epsilon}
+}
+
+// IsFinished CentroidShiftFinishing finish condition
+func (csf *CentroidShiftFinishing) IsFinished(ocm OCModel) bool {
+	csf.mutex.Lock()
+	defer csf.mutex.Unlock()
+	var iterations = ocm.RuntimeFigures()[Iterations]
+	if csf.centroids == nil || iterations != csf.iterations {
+		var centroids = ocm.Centroids()
+		csf.finished = csf.centroids != nil && iterations > csf.iterations &&
+			maxShift(csf.centroids, centroids, ocm.Space()) < csf.Epsilon
+		csf.iterations = iterations
+		csf.centroids = make(Clust, len(centroids))
+		copy(csf.centroids, centroids)
+	}
+	return csf.finished
+}
+
+// Reset forgets the last observed centroids
+func (csf *CentroidShiftFinishing) Reset() {
+	csf.mutex.Lock()
+	defer csf.mutex.Unlock()
+	csf.centroids = nil
+	csf.finished = false
+}
+
+// maxShift returns the maximal distance between centroids with the same label, infinity if the number of centroids changed
+func maxShift(previous Clust, centroids Clust, space Space) (shift float64) {
+	if len(previous) != len(centroids) {
+		return math.Inf(1)
+	}
+	for i := range centroids {
+		shift = math.Max(shift, space.Dist(previous[i], centroids[i]))
+	}
+	return
+}
+
+// LossPlateauFinishing finishes when the relative improvement of the Loss runtime figure over Iter iterations
+// is lower than Threshold. A finishing instance must not be shared by several algorithms
+type LossPlateauFinishing struct {
+	Iter       int
+	Threshold  float64
+	mutex      sync.Mutex
+	iterations float64   // iterations of the last observed loss
+	losses     []float64 // last observed losses, at most Iter+1
+	finished   bool
+}
+
+// NewLossPlateauFinishing returns new instance
+func NewLossPlateauFinishing(iter int, threshold float64) *LossPlateauFinishing {
+	return &LossPlateauFinishing{Iter: iter, Threshold: threshold}
+}
+
+// IsFinished LossPlateauFinishing finish condition
+func (lpf *LossPlateauFinishing) IsFinished(ocm OCModel) bool {
+	lpf.mutex.Lock()
+	defer lpf.mutex.Unlock()
+	var rf = ocm.RuntimeFigures()
+	var loss, ok = rf[Loss]
+	var iterations = rf[Iterations]
+	var window = lpf.Iter + 1
+	if window < 2 {
+		window = 2
+	}
+	if ok && (lpf.losses == nil || iterations != lpf.iterations) {
+		lpf.iterations = iterations
+		lpf.losses = append(lpf.losses, loss)
+		if len(lpf.losses) > window {
+			lpf.losses = lpf.losses[1:]
+		}
+		if len(lpf.losses) == window {
+			var first = lpf.losses[0]
+			var improvement = first - loss
+			if first != 0 {
+				improvement /= math.Abs(first)
+			}
+			lpf.finished = improvement < lpf.Threshold
+		}
+	}
+	return lpf.finished
+}
+
+// Reset forgets the observed losses
+func (lpf *LossPlateauFinishing) Reset() {
+	lpf.mutex.Lock()
+	defer lpf.mutex.Unlock()
+	lpf.losses = nil
+	lpf.finished = false
+}
+
+// IdleDataFinishing finishes when no element has been pushed for Idle duration.
+// The duration is counted at least from the start of the algorithm run
+type IdleDataFinishing struct {
+	Idle  time.Duration
+	mutex sync.Mutex
+	start time.Time // first observation of the current run
+}
+
+// NewIdleDataFinishing returns new instance
+func NewIdleDataFinishing(idle time.Duration) *IdleDataFinishing {
+	return &IdleDataFinishing{Idle: idle}
+}
+
+// IsFinished IdleDataFinishing finish condition
+func (idf *IdleDataFinishing) IsFinished(ocm OCModel) bool {
+	idf.mutex.Lock()
+	defer idf.mutex.Unlock()
+	var now = time.Now()
+	if idf.start.IsZero() {
+		idf.start = now
+	}
+	var last = time.Unix(0, int64(ocm.RuntimeFigures()[LastDataTime]*float64(time.Second)))
+	if last.Before(idf.start) {
+		last = idf.start
+	}
+	return now.Sub(last) >= idf.Idle
+}
+
+// Reset starts counting the idle duration from the next observation
+func (idf *IdleDataFinishing) Reset() {
+	idf.mutex.Lock()
+	defer idf.mutex.Unlock()
+	idf.start = time.Time{}
+}
+
+// DurationFinishing finishes when the accumulated running duration of the algorithm reaches Duration
+type DurationFinishing struct {
+	Duration time.Duration
+}
+
+// NewDurationFinishing returns new instance
+func NewDurationFinishing(duration time.Duration) DurationFinishing {
+	return DurationFinishing{Duration: duration}
+}
+
+// IsFinished DurationFinishing finish condition
+func (df DurationFinishing) IsFinished(ocm OCModel) bool {
+	return time.Duration(ocm.RuntimeFigures()[Duration]) >= df.Duration
+}
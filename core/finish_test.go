@@ -0,0 +1,158 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wearelumenai/distclus/core"
+	"github.com/wearelumenai/distclus/euclid"
+)
+
+func newFinishingModel(iterations int, loss float64, centroids core.Clust) core.OCModel {
+	var figures = core.RuntimeFigures{core.Iterations: float64(iterations), core.Loss: loss}
+	return core.NewSimpleOCModel(&core.CtrlConf{}, euclid.Space{}, core.NewOCStatus(core.Running), figures, centroids)
+}
+
+func Test_CentroidShiftFinishing(t *testing.T) {
+	var finishing = core.NewCentroidShiftFinishing(.5)
+	var models = []core.OCModel{
+		newFinishingModel(1, 0, core.Clust{[]float64{0.}, []float64{10.}}),
+		newFinishingModel(2, 0, core.Clust{[]float64{1.}, []float64{10.}}),
+		newFinishingModel(3, 0, core.Clust{[]float64{1.}, []float64{10.}, []float64{20.}}),
+		newFinishingModel(4, 0, core.Clust{[]float64{1.2}, []float64{10.}, []float64{20.}}),
+	}
+	var expected = []bool{false, false, false, true}
+
+	for i, model := range models {
+		if finished := finishing.IsFinished(model); finished != expected[i] {
+			t.Error("wrong finishing at iteration", i+1, finished)
+		}
+		if finished := finishing.IsFinished(model); finished != expected[i] {
+			t.Error("same result expected for the same iteration", i+1, finished)
+		}
+	}
+
+	finishing.Reset()
+
+	if finishing.IsFinished(models[3]) {
+		t.Error("not finished expected after reset")
+	}
+}
+
+func Test_LossPlateauFinishing(t *testing.T) {
+	var finishing = core.NewLossPlateauFinishing(2, .1)
+	var losses = []float64{100, 50, 40, 38, 37}
+	var expected = []bool{false, false, false, false, true}
+
+	for i, loss := range losses {
+		var model = newFinishingModel(i+1, loss, nil)
+		if finished := finishing.IsFinished(model); finished != expected[i] {
+			t.Error("wrong finishing at iteration", i+1, finished)
+		}
+	}
+
+	finishing.Reset()
+
+	if finishing.IsFinished(newFinishingModel(6, 37, nil)) {
+		t.Error("not finished expected after reset")
+	}
+}
+
+func Test_ResetFinishing(t *testing.T) {
+	var finishing = core.NewLossPlateauFinishing(1, .1)
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5, Finishing: core.NewAndFinishing(finishing)}, 3)
+
+	_ = finishing.IsFinished(newFinishingModel(1, 10, nil))
+	_ = finishing.IsFinished(newFinishingModel(2, 10, nil))
+
+	if !finishing.IsFinished(&algo) {
+		t.Error("finished expected")
+	}
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	if iterations := algo.RuntimeFigures()[core.Iterations]; iterations != 5 {
+		t.Error("5 iterations expected after reset", iterations)
+	}
+}
+
+func Test_IdleDataFinishing(t *testing.T) {
+	var finishing = core.NewIdleDataFinishing(20 * time.Millisecond)
+	var model = core.NewSimpleOCModel(&core.CtrlConf{}, euclid.Space{}, core.NewOCStatus(core.Running), core.RuntimeFigures{}, nil)
+
+	if finishing.IsFinished(model) {
+		t.Error("not finished expected at start")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if !finishing.IsFinished(model) {
+		t.Error("finished expected without data")
+	}
+
+	var now = float64(time.Now().UnixNano()) / float64(time.Second)
+	model = core.NewSimpleOCModel(&core.CtrlConf{}, euclid.Space{}, core.NewOCStatus(core.Running), core.RuntimeFigures{core.LastDataTime: now}, nil)
+
+	if finishing.IsFinished(model) {
+		t.Error("not finished expected after a push")
+	}
+}
+
+func Test_DurationFinishing(t *testing.T) {
+	var finishing = core.NewOrFinishing(core.NewIterFinishing(100, 0), core.NewDurationFinishing(time.Second))
+	var model = newFinishingModel(1, 0, nil)
+	model.RuntimeFigures()[core.Duration] = float64(time.Second / 2)
+
+	if finishing.IsFinished(model) {
+		t.Error("not finished expected")
+	}
+
+	model.RuntimeFigures()[core.Duration] = float64(time.Second)
+
+	if !finishing.IsFinished(model) {
+		t.Error("finished expected")
+	}
+}
+
+func Test_IdleDataFinishingAlgo(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Finishing: core.NewIdleDataFinishing(50 * time.Millisecond)}, 3)
+
+	var err = algo.Play()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	err = algo.Wait(nil, time.Second)
+
+	if err != nil || algo.Status().Value != core.Ready {
+		t.Error("ready status expected", algo.Status(), err)
+	}
+	if duration := algo.RuntimeFigures()[core.Duration]; duration < float64(50*time.Millisecond) {
+		t.Error("running duration expected", duration)
+	}
+}
+
+func Test_DurationFinishingWithoutIteration(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{}, 3)
+	var err = algo.Init()
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	algo.Impl().(*mockImpl).clust = nil // iterations do not return centroids
+
+	err = algo.Play()
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	err = algo.Wait(core.NewDurationFinishing(50*time.Millisecond), time.Second)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	_ = algo.Stop()
+}
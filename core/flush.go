@@ -0,0 +1,34 @@
+package core
+
+import "time"
+
+// Flush applies the data staged by the impl, between two iterations if running.
+// Data pushed meanwhile may stay staged, as given by the StagedData runtime figure
+func (algo *Algo) Flush() (err error) {
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
+	if err = algo.closedError(); err == nil {
+		err = algo.execute(func() error { return algo.flush(algo.Status()) })
+	}
+	return
+}
+
+// flush applies the data staged by a Flusher impl, and publishes the number of data still staged.
+// The status mutex must not be read locked by the caller
+func (algo *Algo) flush(status OCStatus) (err error) {
+	algo.modelMutex.Lock()
+	defer algo.modelMutex.Unlock()
+	if flusher, ok := algo.impl.(Flusher); ok {
+		err = flusher.Flush(NewSimpleOCModel(algo.conf, algo.space, status, algo.runtimeFigures, algo.centroids))
+	}
+	algo.updateRuntimeFigures()
+	algo.publishModel()
+	return
+}
+
+// leaveStatus applies staged data and saves the running duration before applying the next status received from main routine
+func (algo *Algo) leaveStatus(status OCStatus, next OCStatus, start *time.Time) OCStatus {
+	_ = algo.flush(status)
+	algo.saveDuration(start)
+	return algo.applyStatus(next)
+}
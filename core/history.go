@@ -0,0 +1,73 @@
+package core
+
+import "reflect"
+
+// HistoryEntry is a past model of an algorithm
+type HistoryEntry struct {
+	Version        int
+	Iterations     int
+	Centroids      Clust
+	RuntimeFigures RuntimeFigures
+}
+
+// Version returns the model version, incremented at each centroids change
+func (algo *Algo) Version() int {
+	algo.modelMutex.RLock()
+	defer algo.modelMutex.RUnlock()
+	return algo.version
+}
+
+// History returns the last models, from the oldest to the newest. The size is given by CtrlConf.HistorySize
+func (algo *Algo) History() []HistoryEntry {
+	algo.modelMutex.RLock()
+	defer algo.modelMutex.RUnlock()
+	var history = make([]HistoryEntry, len(algo.history))
+	copy(history, algo.history)
+	return history
+}
+
+// CentroidsAt returns the centroids of a given model version if it is the current one or if it is in the history
+func (algo *Algo) CentroidsAt(version int) (centroids Clust, err error) {
+	algo.modelMutex.RLock()
+	defer algo.modelMutex.RUnlock()
+	if version == algo.version {
+		return algo.centroids, nil
+	}
+	for _, entry := range algo.history {
+		if entry.Version == version {
+			return entry.Centroids, nil
+		}
+	}
+	return nil, ErrUnknownVersion
+}
+
+// updateModel sets the centroids, and records a new model version if they changed.
+// The model mutex must be locked by the caller
+func (algo *Algo) updateModel(centroids Clust) {
+	var changed = !reflect.DeepEqual(algo.centroids, centroids)
+	algo.centroids = centroids
+	if changed {
+		algo.version++
+		algo.record()
+	}
+}
+
+// record the current model in the history and drop the oldest models
+func (algo *Algo) record() {
+	var size = algo.conf.Ctrl().HistorySize
+	if size > 0 {
+		var figures = make(RuntimeFigures, len(algo.runtimeFigures))
+		for key, value := range algo.runtimeFigures {
+			figures[key] = value
+		}
+		algo.history = append(algo.history, HistoryEntry{
+			Version:        algo.version,
+			Iterations:     algo.iterations,
+			Centroids:      algo.centroids,
+			RuntimeFigures: figures,
+		})
+	}
+	if len(algo.history) > size {
+		algo.history = algo.history[len(algo.history)-size:]
+	}
+}
@@ -0,0 +1,52 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+func Test_History(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 1, HistorySize: 2}, 10)
+
+	_ = algo.Init()
+
+	if algo.Version() != 1 {
+		t.Error("version 1 expected", algo.Version())
+	}
+
+	for i := 0; i < 2; i++ {
+		_ = algo.Push(nil)
+		var err = algo.Batch()
+		if err != nil {
+			t.Error("no error expected", err)
+		}
+	}
+
+	if algo.Version() != 3 {
+		t.Error("version 3 expected", algo.Version())
+	}
+
+	var history = algo.History()
+	if len(history) != 2 || history[0].Version != 2 || history[1].Version != 3 {
+		t.Error("versions 2 and 3 expected", history)
+	}
+
+	centroids, err := algo.CentroidsAt(2)
+
+	if err != nil || len(centroids) != 11 {
+		t.Error("11 centroids expected", centroids, err)
+	}
+
+	centroids, err = algo.CentroidsAt(3)
+
+	if err != nil || len(centroids) != 12 {
+		t.Error("12 centroids expected", centroids, err)
+	}
+
+	_, err = algo.CentroidsAt(1)
+
+	if err != core.ErrUnknownVersion {
+		t.Error("unknown version expected", err)
+	}
+}
@@ -11,3 +11,39 @@ type Impl interface {
 	// Get a copy of  impl
 	Copy(OCModel) (Impl, error)
 }
+
+// Reconfigurable is implemented by impls able to take into account a new configuration or space
+// without losing their state. The model holds the new configuration and space with current centroids,
+// and the returned centroids replace the current ones
+type Reconfigurable interface {
+	Reconfigure(OCModel) (Clust, error)
+}
+
+// WeightedPusher is implemented by impls which accept weighted elements.
+// Push is equivalent to PushWeighted with a weight of 1
+type WeightedPusher interface {
+	PushWeighted(Elemt, float64, OCModel) error
+}
+
+// BatchPusher is implemented by impls which push several elements at once.
+// The returned slice gives the error of each element, or is nil if all elements are pushed
+type BatchPusher interface {
+	PushBatch([]Elemt, OCModel) []error
+}
+
+// Flusher is implemented by impls which stage data pushed while the algorithm is alive.
+// Flush applies staged data, the controller calls it before each status change
+type Flusher interface {
+	Flush(OCModel) error
+}
+
+// Deleter is implemented by impls which remove data matching a predicate.
+// Delete returns the centroids without the removed data and the number of removed data
+type Deleter interface {
+	Delete(func(Elemt) bool, OCModel) (Clust, int, error)
+}
+
+// CardinalityCounter is implemented by impls which count the elements of each cluster
+type CardinalityCounter interface {
+	Cardinalities(OCModel) []int
+}
@@ -10,11 +10,9 @@ type OCModel interface {
 	RuntimeFigures() RuntimeFigures // clustering figures
 }
 
-// Centroids Get the centroids currently found by the algorithm
+// Centroids Get the centroids currently found by the algorithm, from the last published model view
 func (algo *Algo) Centroids() (centroids Clust) {
-	algo.modelMutex.RLock()
-	defer algo.modelMutex.RUnlock()
-	return algo.centroids
+	return algo.loadView().Centroids
 }
 
 // Space returns space
@@ -24,11 +22,9 @@ func (algo *Algo) Space() Space {
 	return algo.space
 }
 
-// RuntimeFigures returns specific algo properties
+// RuntimeFigures returns a copy of specific algo properties, from the last published model view
 func (algo *Algo) RuntimeFigures() (figures RuntimeFigures) {
-	algo.modelMutex.RLock()
-	defer algo.modelMutex.RUnlock()
-	return algo.runtimeFigures
+	return copyFigures(algo.loadView().RuntimeFigures)
 }
 
 // Conf returns configuration
@@ -1,21 +1,37 @@
 package core
 
 type dbaPartition struct {
-	dbas  Clust
-	cards []int
+	dbas    Clust
+	weights []float64
+	loss    float64
 }
 
-func parReduceDBA(centroids Clust, data []Elemt, space Space, degree int) (Clust, []int) {
+func parReduceDBA(centroids Clust, data []Elemt, weights []float64, space Space, norm float64, degree int) (Clust, []float64, float64) {
 	var parts = make([]dbaPartition, degree)
 
 	var process = func(start int, end int, rank int) {
-		dbaReduce(space, centroids, data[start:end], &parts[rank])
+		dbaReduce(space, centroids, data[start:end], weightsSlice(weights, start, end), norm, &parts[rank])
 	}
 
 	Par(process, len(data), degree)
 
 	var aggr = dbaAggregate(parts, space)
-	return buildResult(centroids, aggr)
+	var result, totals = buildResult(centroids, aggr)
+	return result, totals, aggr.loss
+}
+
+func stableReduceDBA(centroids Clust, data []Elemt, weights []float64, space Space, norm float64, degree int) (Clust, []float64, float64) {
+	var parts = make([]dbaPartition, stableBlocks(len(data)))
+
+	var process = func(start int, end int, block int) {
+		dbaReduce(space, centroids, data[start:end], weightsSlice(weights, start, end), norm, &parts[block])
+	}
+
+	StablePar(process, len(data), degree)
+
+	var aggr = dbaAggregate(parts, space)
+	var result, totals = buildResult(centroids, aggr)
+	return result, totals, aggr.loss
 }
 
 func parDBAForLabels(centroids Clust, data []Elemt, labels []int, space Space, degree int) ([]Elemt, []int) {
@@ -29,15 +45,20 @@ func parDBAForLabels(centroids Clust, data []Elemt, labels []int, space Space, d
 
 	var aggr = dbaAggregate(parts, space)
 
-	return aggr.dbas, aggr.cards
+	return aggr.dbas, toCards(aggr.weights)
 }
 
 func dbaReduceForLabels(space Space, centroids Clust, elemts []Elemt, labels []int, part *dbaPartition) {
-	part.dbas, part.cards = centroids.ReduceDBAForLabels(elemts, labels, space)
+	var cards []int
+	part.dbas, cards = centroids.ReduceDBAForLabels(elemts, labels, space)
+	part.weights = make([]float64, len(cards))
+	for i, card := range cards {
+		part.weights[i] = float64(card)
+	}
 }
 
-func dbaReduce(space Space, centroids Clust, elemts []Elemt, part *dbaPartition) {
-	part.dbas, part.cards = centroids.ReduceDBA(elemts, space)
+func dbaReduce(space Space, centroids Clust, elemts []Elemt, weights []float64, norm float64, part *dbaPartition) {
+	part.dbas, part.weights, part.loss = centroids.ReduceWeightedDBALoss(elemts, weights, space, norm)
 }
 
 func dbaAggregate(parts []dbaPartition, space Space) dbaPartition {
@@ -45,10 +66,11 @@ func dbaAggregate(parts []dbaPartition, space Space) dbaPartition {
 	for _, other := range parts {
 		if aggregate.dbas == nil {
 			aggregate.dbas = other.dbas
-			aggregate.cards = other.cards
+			aggregate.weights = other.weights
 		} else {
 			aggregate = dbaCombine(space, aggregate, other)
 		}
+		aggregate.loss += other.loss
 	}
 
 	return aggregate
@@ -57,30 +79,30 @@ func dbaAggregate(parts []dbaPartition, space Space) dbaPartition {
 func dbaCombine(space Space, aggregate dbaPartition, other dbaPartition) dbaPartition {
 	for i := 0; i < len(aggregate.dbas); i++ {
 		switch {
-		case aggregate.cards[i] == 0:
+		case aggregate.weights[i] == 0:
 			aggregate.dbas[i] = other.dbas[i]
-			aggregate.cards[i] = other.cards[i]
+			aggregate.weights[i] = other.weights[i]
 
-		case other.cards[i] > 0:
-			aggregate.dbas[i] = space.Combine(
-				aggregate.dbas[i], aggregate.cards[i],
-				other.dbas[i], other.cards[i],
+		case other.weights[i] > 0:
+			aggregate.dbas[i] = WeightedCombine(space,
+				aggregate.dbas[i], aggregate.weights[i],
+				other.dbas[i], other.weights[i],
 			)
-			aggregate.cards[i] += other.cards[i]
+			aggregate.weights[i] += other.weights[i]
 		}
 	}
 
 	return aggregate
 }
 
-func buildResult(data Clust, aggr dbaPartition) (Clust, []int) {
+func buildResult(data Clust, aggr dbaPartition) (Clust, []float64) {
 	var result = make(Clust, len(aggr.dbas))
 	for i := 0; i < len(data); i++ {
-		if aggr.cards[i] > 0 {
+		if aggr.weights[i] > 0 {
 			result[i] = aggr.dbas[i]
 		} else {
 			result[i] = data[i]
 		}
 	}
-	return result, aggr.cards
+	return result, aggr.weights
 }
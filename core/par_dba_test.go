@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/wearelumenai/distclus/core"
@@ -23,3 +24,29 @@ func TestClust_ParReduceDBA(t *testing.T) {
 		test.AssertArrayEqual(t, seqCards, parCards)
 	}
 }
+
+func TestClust_StableReduceWeightedDBA(t *testing.T) {
+	var data = make([]core.Elemt, 0, len(test.Vectors)*100)
+	var weights = make([]float64, 0, len(test.Vectors)*100)
+	var centroids = core.Clust(test.Vectors[0:3])
+	for i := 0; i < 100; i++ {
+		data = append(data, test.Vectors...)
+		for j := range test.Vectors {
+			weights = append(weights, float64(1+(i+j)%3))
+		}
+	}
+
+	var dbas, totals = centroids.StableReduceWeightedDBA(data, weights, euclid.Space{}, 1)
+	var loss = centroids.StableWeightedTotalLoss(data, weights, euclid.Space{}, 2, 1)
+	for degree := 2; degree < 20; degree++ {
+		var parDbas, parTotals = centroids.StableReduceWeightedDBA(data, weights, euclid.Space{}, degree)
+		var parLoss = centroids.StableWeightedTotalLoss(data, weights, euclid.Space{}, 2, degree)
+
+		if !reflect.DeepEqual(dbas, parDbas) || !reflect.DeepEqual(totals, parTotals) || loss != parLoss {
+			t.Error("identical results expected", degree)
+		}
+	}
+
+	var seqDbas, _ = centroids.ReduceWeightedDBA(data, weights, euclid.Space{})
+	test.AssertCentroids(t, seqDbas, dbas)
+}
@@ -0,0 +1,277 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+// ModelVersion is the version of the exported model format
+const ModelVersion = 1
+
+// ModelFormat is the encoding of an exported model
+type ModelFormat int
+
+// ModelFormat const values
+const (
+	JSONModel   ModelFormat = iota // human readable encoding
+	BinaryModel                    // compact gob encoding
+)
+
+// prefix of binary model files
+var binaryModelMagic = []byte("DCLM")
+
+// Predictor predicts with exported centroids, without impl, buffer nor go routines
+type Predictor struct {
+	centroids Clust
+	cards     []int
+	space     Space
+}
+
+// jsonModel is the JSON content of a model file
+type jsonModel struct {
+	Version       int               `json:"version"`
+	Space         string            `json:"space"`
+	SpaceConf     json.RawMessage   `json:"spaceConf,omitempty"`
+	Centroids     []json.RawMessage `json:"centroids"`
+	Cardinalities []int             `json:"cardinalities,omitempty"`
+}
+
+// binaryModelHeader starts a binary model file. It is followed by the space configuration if any, then the body
+type binaryModelHeader struct {
+	Version   int
+	Space     string
+	SpaceConf bool
+}
+
+// binaryModelBody ends a binary model file.
+// Centroids are encoded by the space if it is an ElemtCodec, otherwise by gob
+type binaryModelBody struct {
+	Centroids        Clust
+	EncodedCentroids [][]byte
+	Cardinalities    []int
+}
+
+// NewPredictor creates a predictor. Cardinalities are optional
+func NewPredictor(centroids Clust, cards []int, space Space) *Predictor {
+	return &Predictor{
+		centroids: centroids,
+		cards:     cards,
+		space:     space,
+	}
+}
+
+// Predictor returns a predictor with a copy of the current centroids,
+// and their cardinalities if the impl is a CardinalityCounter
+func (algo *Algo) Predictor() (predictor *Predictor, err error) {
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
+	if err = algo.closedError(); err != nil {
+		return
+	}
+	err = algo.execute(func() error {
+		var model = NewSimpleOCModel(algo.Conf(), algo.Space(), algo.Status(), algo.RuntimeFigures(), algo.Centroids())
+		var centroids = make(Clust, len(model.Centroids()))
+		copy(centroids, model.Centroids())
+		var cards []int
+		if counter, ok := algo.impl.(CardinalityCounter); ok && len(centroids) > 0 {
+			cards = counter.Cardinalities(model)
+		}
+		predictor = NewPredictor(centroids, cards, model.Space())
+		return nil
+	})
+	return
+}
+
+// Predict the cluster of an element
+func (predictor *Predictor) Predict(elemt Elemt) (pred Elemt, label int, dist float64) {
+	return predictor.centroids.Assign(elemt, predictor.space)
+}
+
+// MapLabel assigns elements to centroids
+func (predictor *Predictor) MapLabel(elemts []Elemt) (labels []int, dists []float64) {
+	return predictor.centroids.MapLabel(elemts, predictor.space)
+}
+
+// Centroids returns predictor centroids
+func (predictor *Predictor) Centroids() Clust {
+	return predictor.centroids
+}
+
+// Cardinalities returns the number of elements of each cluster if known, otherwise nil
+func (predictor *Predictor) Cardinalities() []int {
+	return predictor.cards
+}
+
+// Space returns predictor space
+func (predictor *Predictor) Space() Space {
+	return predictor.space
+}
+
+// Export writes a model file in the given format. The space must be an IdentifiedSpace
+func (predictor *Predictor) Export(writer io.Writer, format ModelFormat) (err error) {
+	var space, ok = predictor.space.(IdentifiedSpace)
+	if !ok {
+		return ErrNotIdentifiedSpace
+	}
+	var name, conf = space.Identity()
+	if format == BinaryModel {
+		err = predictor.exportBinary(writer, name, conf)
+	} else {
+		err = predictor.exportJSON(writer, name, conf)
+	}
+	return
+}
+
+func (predictor *Predictor) exportJSON(writer io.Writer, name string, conf SpaceConf) (err error) {
+	var model = jsonModel{
+		Version:       ModelVersion,
+		Space:         name,
+		Centroids:     make([]json.RawMessage, len(predictor.centroids)),
+		Cardinalities: predictor.cards,
+	}
+	if conf != nil {
+		model.SpaceConf, err = json.Marshal(conf)
+	}
+	var codec, ok = predictor.space.(ElemtCodec)
+	for i := 0; i < len(predictor.centroids) && err == nil; i++ {
+		if ok {
+			model.Centroids[i], err = codec.EncodeJSONElemt(predictor.centroids[i])
+		} else {
+			model.Centroids[i], err = json.Marshal(predictor.centroids[i])
+		}
+	}
+	if err == nil {
+		err = json.NewEncoder(writer).Encode(model)
+	}
+	return
+}
+
+func (predictor *Predictor) exportBinary(writer io.Writer, name string, conf SpaceConf) (err error) {
+	var encoder = gob.NewEncoder(writer)
+	if _, err = writer.Write(binaryModelMagic); err == nil {
+		err = encoder.Encode(binaryModelHeader{
+			Version:   ModelVersion,
+			Space:     name,
+			SpaceConf: conf != nil,
+		})
+	}
+	if err == nil && conf != nil {
+		err = encoder.Encode(conf)
+	}
+	var body = binaryModelBody{Cardinalities: predictor.cards}
+	if codec, ok := predictor.space.(ElemtCodec); ok {
+		body.EncodedCentroids = make([][]byte, len(predictor.centroids))
+		for i := 0; i < len(predictor.centroids) && err == nil; i++ {
+			body.EncodedCentroids[i], err = codec.EncodeElemt(predictor.centroids[i])
+		}
+	} else {
+		body.Centroids = predictor.centroids
+	}
+	if err == nil {
+		err = encoder.Encode(body)
+	}
+	return
+}
+
+// LoadPredictor reads a model file written by Export, whatever its format.
+// The space of the model must be registered
+func LoadPredictor(reader io.Reader) (predictor *Predictor, err error) {
+	var buffered = bufio.NewReader(reader)
+	var magic, _ = buffered.Peek(len(binaryModelMagic))
+	if bytes.Equal(magic, binaryModelMagic) {
+		_, _ = buffered.Discard(len(binaryModelMagic))
+		predictor, err = loadBinary(buffered)
+	} else {
+		predictor, err = loadJSON(buffered)
+	}
+	return
+}
+
+func loadJSON(reader io.Reader) (predictor *Predictor, err error) {
+	var model jsonModel
+	var registration spaceRegistration
+	var space Space
+	if err = json.NewDecoder(reader).Decode(&model); err == nil {
+		registration, err = checkModel(model.Version, model.Space)
+	}
+	if err == nil {
+		space, err = registration.builder(func(conf SpaceConf) error {
+			if len(model.SpaceConf) == 0 {
+				return nil
+			}
+			return json.Unmarshal(model.SpaceConf, conf)
+		})
+	}
+	var centroids = make(Clust, len(model.Centroids))
+	var codec, ok = space.(ElemtCodec)
+	for i := 0; i < len(centroids) && err == nil; i++ {
+		if ok {
+			centroids[i], err = codec.DecodeJSONElemt(model.Centroids[i])
+		} else {
+			var elemt = reflect.New(registration.elemtType)
+			err = json.Unmarshal(model.Centroids[i], elemt.Interface())
+			centroids[i] = elemt.Elem().Interface()
+		}
+	}
+	if err == nil {
+		predictor = NewPredictor(centroids, model.Cardinalities, space)
+	}
+	return
+}
+
+func loadBinary(reader io.Reader) (predictor *Predictor, err error) {
+	var decoder = gob.NewDecoder(reader)
+	var header binaryModelHeader
+	var body binaryModelBody
+	var registration spaceRegistration
+	var space Space
+	if err = decoder.Decode(&header); err == nil {
+		registration, err = checkModel(header.Version, header.Space)
+	}
+	if err == nil {
+		space, err = registration.builder(func(conf SpaceConf) error {
+			if !header.SpaceConf {
+				return nil
+			}
+			return decoder.Decode(conf)
+		})
+	}
+	if err == nil {
+		err = decoder.Decode(&body)
+	}
+	if err == nil && body.EncodedCentroids != nil {
+		body.Centroids, err = decodeElemts(space, body.EncodedCentroids)
+	}
+	if err == nil {
+		predictor = NewPredictor(body.Centroids, body.Cardinalities, space)
+	}
+	return
+}
+
+// decodeElemts decodes elements with the space codec
+func decodeElemts(space Space, data [][]byte) (elemts []Elemt, err error) {
+	var codec, ok = space.(ElemtCodec)
+	if !ok {
+		return nil, ErrElemtEncoding
+	}
+	elemts = make([]Elemt, len(data))
+	for i := 0; i < len(data) && err == nil; i++ {
+		elemts[i], err = codec.DecodeElemt(data[i])
+	}
+	return
+}
+
+// checkModel version and returns the registered space
+func checkModel(version int, name string) (registration spaceRegistration, err error) {
+	var ok bool
+	if version != ModelVersion {
+		err = ErrModelVersion
+	} else if registration, ok = getSpaceRegistration(name); !ok {
+		err = ErrUnknownSpace
+	}
+	return
+}
@@ -0,0 +1,94 @@
+package core_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+	"github.com/wearelumenai/distclus/euclid"
+)
+
+func Test_PredictorExport(t *testing.T) {
+	var centroids = core.Clust{[]float64{0., 0.}, []float64{10., 10.}}
+	var predictor = core.NewPredictor(centroids, []int{3, 4}, euclid.NewSpace())
+
+	for _, format := range []core.ModelFormat{core.JSONModel, core.BinaryModel} {
+		var b bytes.Buffer
+		var err = predictor.Export(&b, format)
+
+		if err != nil {
+			t.Error("no error expected", err)
+		}
+
+		loaded, err := core.LoadPredictor(&b)
+
+		if err != nil {
+			t.Error("no error expected", err)
+		}
+
+		if !reflect.DeepEqual(loaded.Centroids(), centroids) {
+			t.Error("same centroids expected", loaded.Centroids())
+		}
+
+		if !reflect.DeepEqual(loaded.Cardinalities(), []int{3, 4}) {
+			t.Error("same cardinalities expected", loaded.Cardinalities())
+		}
+
+		var _, label, _ = loaded.Predict([]float64{9., 8.})
+		if label != 1 {
+			t.Error("label 1 expected", label)
+		}
+
+		var labels, _ = loaded.MapLabel([]core.Elemt{[]float64{1., 0.}, []float64{9., 8.}})
+		if !reflect.DeepEqual(labels, []int{0, 1}) {
+			t.Error("labels expected", labels)
+		}
+	}
+}
+
+func Test_PredictorErrors(t *testing.T) {
+	var predictor = core.NewPredictor(core.Clust{}, nil, mockSpace{})
+
+	var err = predictor.Export(&bytes.Buffer{}, core.JSONModel)
+
+	if err != core.ErrNotIdentifiedSpace {
+		t.Error("not identified error expected", err)
+	}
+
+	_, err = core.LoadPredictor(strings.NewReader(`{"version":1,"space":"unknown","centroids":[]}`))
+
+	if err != core.ErrUnknownSpace {
+		t.Error("unknown space error expected", err)
+	}
+
+	_, err = core.LoadPredictor(strings.NewReader(`{"version":0,"space":"euclid","centroids":[]}`))
+
+	if err != core.ErrModelVersion {
+		t.Error("version error expected", err)
+	}
+}
+
+func Test_AlgoPredictor(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 1}, 3)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var predictor, predictorErr = algo.Predictor()
+	if predictorErr != nil {
+		t.Error("no error expected", predictorErr)
+	}
+
+	if len(predictor.Centroids()) != len(algo.Centroids()) {
+		t.Error("same centroids expected", predictor.Centroids())
+	}
+
+	if predictor.Cardinalities() != nil {
+		t.Error("no cardinalities expected", predictor.Cardinalities())
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import "golang.org/x/exp/rand"
+
+// Seed is the seed of the random streams of a reproducible algorithm
+const Seed = "seed"
+
+// Random streams derived from the configuration seed
+const (
+	ImplStream    uint64 = iota // impl random generator: initializers, center store and samplers
+	DistribStream               // mcmc proposal distribution
+)
+
+// stableBlockSize is the number of elements reduced together by order-stable reductions
+const stableBlockSize = 256
+
+// Reproducible is true if a seed is given. Random streams are derived from the seed,
+// and parallel reductions do not depend on the degree of parallelism
+func (conf *CtrlConf) Reproducible() bool {
+	return conf.Seed != 0
+}
+
+// NewRand returns the random generator of a stream derived from the seed.
+// The same seed and stream always give the same sequence
+func (conf *CtrlConf) NewRand(stream uint64) *rand.Rand {
+	return rand.New(rand.NewSource(splitMix(conf.Seed + stream*0x9e3779b97f4a7c15)))
+}
+
+// splitMix scrambles a seed so that close seeds and streams give independent sequences
+func splitMix(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// StablePar runs a function in parallel over data blocks of fixed size. The rank given to the function is the block index,
+// so that partitions, and the order in which their results are aggregated, do not depend on the degree of parallelism
+func StablePar(process PartitionProcess, size int, degree int) {
+	var blocks = stableBlocks(size)
+	if degree < 1 {
+		degree = 1
+	} else if degree > blocks {
+		degree = blocks
+	}
+	Par(func(first int, last int, _ int) {
+		for block := first; block < last; block++ {
+			var start = block * stableBlockSize
+			var end = start + stableBlockSize
+			if end > size {
+				end = size
+			}
+			process(start, end, block)
+		}
+	}, blocks, degree)
+}
+
+// stableBlocks returns the number of blocks of data processed by StablePar, at least one
+func stableBlocks(size int) int {
+	var blocks = (size + stableBlockSize - 1) / stableBlockSize
+	if blocks == 0 {
+		blocks = 1
+	}
+	return blocks
+}
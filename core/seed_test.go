@@ -0,0 +1,47 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+func TestCtrlConf_NewRand(t *testing.T) {
+	var conf = core.CtrlConf{Seed: 42}
+	var other = core.CtrlConf{Seed: 43}
+
+	if !conf.Reproducible() || (&core.CtrlConf{}).Reproducible() {
+		t.Error("reproducible with a seed expected")
+	}
+
+	var r1, r2 = conf.NewRand(core.ImplStream), conf.NewRand(core.ImplStream)
+	var d, o = conf.NewRand(core.DistribStream), other.NewRand(core.ImplStream)
+	for i := 0; i < 10; i++ {
+		var x = r1.Uint64()
+		if x != r2.Uint64() {
+			t.Error("identical streams expected")
+		}
+		if x == d.Uint64() || x == o.Uint64() {
+			t.Error("independent streams expected")
+		}
+	}
+}
+
+func TestStablePar(t *testing.T) {
+	for _, size := range []int{0, 1, 255, 256, 257, 1000} {
+		var covered = make([]int, size)
+		core.StablePar(func(start, end, block int) {
+			if start != block*256 {
+				t.Error("block start expected", start, block)
+			}
+			for i := start; i < end; i++ {
+				covered[i]++
+			}
+		}, size, 3)
+		for i := range covered {
+			if covered[i] != 1 {
+				t.Error("each element once expected", size, i)
+			}
+		}
+	}
+}
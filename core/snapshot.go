@@ -0,0 +1,124 @@
+package core
+
+import (
+	"encoding/gob"
+	"io"
+	"time"
+)
+
+// version of the snapshot format
+const snapshotVersion = 1
+
+// Snapshotable is implemented by impls which save and restore their internal state.
+// Elements are gob encoded, thus their concrete types must be registered with gob.Register
+type Snapshotable interface {
+	Snapshot(*gob.Encoder) error
+	Restore(*gob.Decoder) error
+}
+
+// algoSnapshot is the saved state of an algorithm
+type algoSnapshot struct {
+	Version        int
+	Status         ClustStatus
+	Centroids      Clust
+	RuntimeFigures RuntimeFigures
+	NewData        int
+	PushedData     int
+	Iterations     int
+	ModelVersion   int
+	Duration       time.Duration
+	LastDataTime   int64
+	Impl           bool // true if followed by the impl state
+}
+
+// Snapshot writes the algorithm state, followed by the impl state if the impl is Snapshotable.
+// If the algorithm is running, the state is saved between two iterations.
+// Random generators are not saved: a restored algorithm draws from the generators of its configuration,
+// which restart from the seed of a reproducible configuration
+func (algo *Algo) Snapshot(writer io.Writer) error {
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
+	if err := algo.closedError(); err != nil {
+		return err
+	}
+	return algo.execute(func() error { return algo.snapshot(gob.NewEncoder(writer)) })
+}
+
+func (algo *Algo) snapshot(encoder *gob.Encoder) (err error) {
+	var impl, ok = algo.impl.(Snapshotable)
+	var status = algo.Status().Value
+	algo.modelMutex.RLock()
+	var snapshot = algoSnapshot{
+		Version:        snapshotVersion,
+		Status:         status,
+		Centroids:      algo.centroids,
+		RuntimeFigures: algo.runtimeFigures,
+		NewData:        algo.newData,
+		PushedData:     algo.pushedData,
+		Iterations:     algo.iterations,
+		ModelVersion:   algo.version,
+		Duration:       time.Duration(algo.runtimeFigures[Duration]),
+		LastDataTime:   algo.lastDataTime,
+		Impl:           ok,
+	}
+	err = encoder.Encode(snapshot)
+	algo.modelMutex.RUnlock()
+	if err == nil && ok {
+		err = impl.Snapshot(encoder)
+	}
+	return
+}
+
+// Restore creates an algorithm with a state written by Snapshot.
+// The configuration, impl and space must be compatible with those of the saved algorithm.
+// A running algorithm is restored as ready
+func Restore(reader io.Reader, conf Conf, impl Impl, space Space) (algo *Algo, err error) {
+	var decoder = gob.NewDecoder(reader)
+	var snapshot algoSnapshot
+	if err = decoder.Decode(&snapshot); err == nil {
+		algo = NewAlgo(conf, impl, space)
+		if err = algo.restore(decoder, snapshot); err != nil {
+			algo = nil
+		}
+	}
+	return
+}
+
+func (algo *Algo) restore(decoder *gob.Decoder, snapshot algoSnapshot) (err error) {
+	if snapshot.Version != snapshotVersion {
+		return ErrSnapshotVersion
+	}
+	if snapshot.Impl {
+		if impl, ok := algo.impl.(Snapshotable); ok {
+			err = impl.Restore(decoder)
+		} else {
+			err = ErrNotSnapshotable
+		}
+	}
+	if err == nil {
+		algo.centroids = snapshot.Centroids
+		algo.runtimeFigures = snapshot.RuntimeFigures
+		if algo.runtimeFigures == nil {
+			algo.runtimeFigures = RuntimeFigures{}
+		}
+		algo.newData = snapshot.NewData
+		algo.pushedData = snapshot.PushedData
+		algo.iterations = snapshot.Iterations
+		algo.version = snapshot.ModelVersion
+		algo.duration = snapshot.Duration
+		algo.lastDataTime = snapshot.LastDataTime
+		switch {
+		case algo.centroids == nil:
+		case snapshot.Status == Finished:
+			algo.status = NewOCStatus(Finished)
+		default:
+			algo.status = NewOCStatus(Ready)
+		}
+		algo.publishModel()
+		algo.publishStatus(algo.status)
+		if algo.status.Value != Created {
+			algo.startNotification()
+		}
+	}
+	return
+}
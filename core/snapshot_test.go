@@ -0,0 +1,85 @@
+package core_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+func Test_Snapshot(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 3)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var b bytes.Buffer
+	err = algo.Snapshot(&b)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	restored, err := core.Restore(&b, &mockConf{}, &mockImpl{}, mockSpace{})
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if restored.Status().Value != core.Finished {
+		t.Error("finished expected", restored.Status())
+	}
+
+	if len(restored.Centroids()) != len(algo.Centroids()) {
+		t.Error("same centroids expected", restored.Centroids())
+	}
+
+	var figures = restored.RuntimeFigures()
+	if figures[core.Iterations] != 5 || figures[core.PushedData] != algo.RuntimeFigures()[core.PushedData] {
+		t.Error("same figures expected", figures)
+	}
+}
+
+func Test_SnapshotRunning(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{}, 3)
+
+	var err = algo.Play()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var b bytes.Buffer
+	err = algo.Snapshot(&b)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if algo.Status().Value != core.Running {
+		t.Error("running expected", algo.Status())
+	}
+
+	_ = algo.Stop()
+
+	restored, err := core.Restore(&b, &mockConf{}, &mockImpl{}, mockSpace{})
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if restored.Status().Value != core.Ready {
+		t.Error("ready expected", restored.Status())
+	}
+}
+
+func Test_RestoreError(t *testing.T) {
+	var _, err = core.Restore(bytes.NewBufferString("wrong"), &mockConf{}, &mockImpl{}, mockSpace{})
+
+	if err == nil {
+		t.Error("error expected")
+	}
+}
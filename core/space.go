@@ -1,5 +1,11 @@
 package core
 
+import (
+	"encoding/gob"
+	"reflect"
+	"sync"
+)
+
 // Elemt interface that can be used in a clustering algorithm
 type Elemt interface{}
 
@@ -11,5 +17,73 @@ type Space interface {
 	Dim(data []Elemt) int
 }
 
+// FloatCombiner is implemented by spaces which combine elements with real weights
+type FloatCombiner interface {
+	CombineFloat(elemt1 Elemt, weight1 float64, elemt2 Elemt, weight2 float64) Elemt
+}
+
+// Uncombiner is implemented by spaces which remove an element from a combination.
+// Uncombine returns the combination of weight1 - weight2 from which elemt2 with weight2 has been removed
+type Uncombiner interface {
+	Uncombine(elemt1 Elemt, weight1 float64, elemt2 Elemt, weight2 float64) Elemt
+}
+
 // SpaceConf is a space configuration interface
 type SpaceConf interface{}
+
+// IdentifiedSpace is implemented by spaces which can be exported with a model.
+// The name is the one given to RegisterSpace and the configuration must be serializable
+type IdentifiedSpace interface {
+	Space
+	Identity() (name string, conf SpaceConf)
+}
+
+// ElemtCodec is implemented by spaces which serialize their elements, in a compact binary form and in JSON
+type ElemtCodec interface {
+	EncodeElemt(Elemt) ([]byte, error)
+	DecodeElemt([]byte) (Elemt, error)
+	EncodeJSONElemt(Elemt) ([]byte, error)
+	DecodeJSONElemt([]byte) (Elemt, error)
+}
+
+// SpaceBuilder creates a space from a configuration read with the decode function
+type SpaceBuilder func(decode func(SpaceConf) error) (Space, error)
+
+// registered space
+type spaceRegistration struct {
+	builder   SpaceBuilder
+	elemtType reflect.Type
+}
+
+var spaces = map[string]spaceRegistration{}
+var spacesMutex sync.RWMutex
+
+// RegisterSpace registers a space builder with the prototype of the space elements.
+// The element type is registered with gob
+func RegisterSpace(name string, builder SpaceBuilder, prototype Elemt) {
+	spacesMutex.Lock()
+	defer spacesMutex.Unlock()
+	gob.Register(prototype)
+	spaces[name] = spaceRegistration{
+		builder:   builder,
+		elemtType: reflect.TypeOf(prototype),
+	}
+}
+
+// BuildSpace creates a registered space from a configuration read with the decode function
+func BuildSpace(name string, decode func(SpaceConf) error) (space Space, err error) {
+	var registration, ok = getSpaceRegistration(name)
+	if ok {
+		space, err = registration.builder(decode)
+	} else {
+		err = ErrUnknownSpace
+	}
+	return
+}
+
+func getSpaceRegistration(name string) (registration spaceRegistration, ok bool) {
+	spacesMutex.RLock()
+	defer spacesMutex.RUnlock()
+	registration, ok = spaces[name]
+	return
+}
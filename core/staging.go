@@ -0,0 +1,212 @@
+package core
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy defines the behavior of a push when the staging capacity is reached
+type OverflowPolicy int
+
+// OverflowPolicy const values
+const (
+	DefaultOverflow    OverflowPolicy = iota // buffer default: block for data buffers, error for the streaming buffer
+	BlockOverflow                            // push waits for room
+	TimeoutOverflow                          // push waits for room at most the overflow timeout, then fails with ErrBufferFull
+	DropNewestOverflow                       // the pushed element is dropped
+	DropOldestOverflow                       // the oldest staged element is dropped
+	ErrorOverflow                            // push fails with ErrBufferFull
+)
+
+// StagedData is the number of staged elements
+const StagedData = "stagedData"
+
+// DroppedData is the number of elements dropped by the overflow policy
+const DroppedData = "droppedData"
+
+// WeightedElemt is an element with its weight
+type WeightedElemt struct {
+	Elemt  Elemt
+	Weight float64
+	Time   int64 // push time in nanoseconds since the Unix epoch
+}
+
+// Staging is a bounded queue of elements pushed while an algorithm is alive, with an overflow policy.
+// Elements are queued under a lock, and the capacity is enforced by slots taken by staged elements and pending pushes
+type Staging struct {
+	slots   chan struct{}
+	mutex   sync.Mutex
+	queue   []WeightedElemt
+	policy  OverflowPolicy
+	timeout time.Duration
+	dropped int64
+}
+
+// StagingCounter is implemented by impls which stage pushed data.
+// Staged and dropped data are given in the runtime figures
+type StagingCounter interface {
+	Staging() *Staging
+}
+
+// NewStaging creates a staging queue with the given capacity. The default policy blocks
+func NewStaging(size int, policy OverflowPolicy, timeout time.Duration) *Staging {
+	if policy == DefaultOverflow {
+		policy = BlockOverflow
+	}
+	return &Staging{
+		slots:   make(chan struct{}, size),
+		policy:  policy,
+		timeout: timeout,
+	}
+}
+
+// Push stages an element according to the overflow policy
+func (s *Staging) Push(elemt Elemt, weight float64) (err error) {
+	var staged = WeightedElemt{elemt, weight, time.Now().UnixNano()}
+	select {
+	case s.slots <- struct{}{}:
+		s.enqueue(staged)
+		return
+	default:
+	}
+	switch s.policy {
+	case BlockOverflow:
+		s.slots <- struct{}{}
+		s.enqueue(staged)
+	case TimeoutOverflow:
+		var timer = time.NewTimer(s.timeout)
+		defer timer.Stop()
+		select {
+		case s.slots <- struct{}{}:
+			s.enqueue(staged)
+		case <-timer.C:
+			err = ErrBufferFull
+		}
+	case DropNewestOverflow:
+		atomic.AddInt64(&s.dropped, 1)
+	case DropOldestOverflow:
+		s.replaceOldest(staged)
+	default:
+		err = ErrBufferFull
+	}
+	return
+}
+
+// enqueue appends an element whose slot is taken
+func (s *Staging) enqueue(staged WeightedElemt) {
+	s.mutex.Lock()
+	s.queue = append(s.queue, staged)
+	s.mutex.Unlock()
+}
+
+// replaceOldest drops the oldest staged element in favor of the element, which takes its slot.
+// The element is dropped if the capacity is zero
+func (s *Staging) replaceOldest(staged WeightedElemt) {
+	for cap(s.slots) > 0 {
+		select {
+		case s.slots <- struct{}{}:
+			s.enqueue(staged)
+			return
+		default:
+		}
+		s.mutex.Lock()
+		var replaced = len(s.queue) > 0
+		if replaced {
+			s.queue[0] = WeightedElemt{}
+			s.queue = append(s.queue[1:], staged)
+		}
+		s.mutex.Unlock()
+		if replaced {
+			break
+		}
+		runtime.Gosched() // slots are taken by pending pushes
+	}
+	atomic.AddInt64(&s.dropped, 1)
+}
+
+// release frees the slots of removed elements
+func (s *Staging) release(count int) {
+	for i := 0; i < count; i++ {
+		<-s.slots
+	}
+}
+
+// Next returns the oldest staged element if any
+func (s *Staging) Next() (staged WeightedElemt, ok bool) {
+	s.mutex.Lock()
+	if ok = len(s.queue) > 0; ok {
+		staged = s.queue[0]
+		s.queue[0] = WeightedElemt{}
+		s.queue = s.queue[1:]
+	}
+	s.mutex.Unlock()
+	if ok {
+		s.release(1)
+	}
+	return
+}
+
+// Drain returns all staged elements
+func (s *Staging) Drain() (staged []WeightedElemt) {
+	s.mutex.Lock()
+	staged, s.queue = s.queue, nil
+	s.mutex.Unlock()
+	s.release(len(staged))
+	return
+}
+
+// Delete removes the staged elements matching the predicate and returns them.
+// Remaining elements keep their order, and concurrent pushes are staged after them
+func (s *Staging) Delete(predicate func(Elemt) bool) (deleted []WeightedElemt) {
+	s.mutex.Lock()
+	var kept = make([]WeightedElemt, 0, len(s.queue))
+	for _, staged := range s.queue {
+		if predicate(staged.Elemt) {
+			deleted = append(deleted, staged)
+		} else {
+			kept = append(kept, staged)
+		}
+	}
+	s.queue = kept
+	s.mutex.Unlock()
+	s.release(len(deleted))
+	return
+}
+
+// Staged returns a copy of the staged elements, which stay staged
+func (s *Staging) Staged() (staged []WeightedElemt) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append(staged, s.queue...)
+}
+
+// restage stages saved elements with their push time. Elements beyond the capacity are dropped
+func (s *Staging) restage(staged []WeightedElemt) {
+	for _, elemt := range staged {
+		select {
+		case s.slots <- struct{}{}:
+			s.enqueue(elemt)
+		default:
+			atomic.AddInt64(&s.dropped, 1)
+		}
+	}
+}
+
+// Len returns the number of staged elements
+func (s *Staging) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.queue)
+}
+
+// Cap returns the staging capacity
+func (s *Staging) Cap() int {
+	return cap(s.slots)
+}
+
+// Dropped returns the number of elements dropped by the overflow policy
+func (s *Staging) Dropped() int {
+	return int(atomic.LoadInt64(&s.dropped))
+}
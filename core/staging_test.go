@@ -0,0 +1,118 @@
+package core_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+func stage(t *testing.T, policy core.OverflowPolicy, timeout time.Duration) (staging *core.Staging, errs []error) {
+	staging = core.NewStaging(2, policy, timeout)
+	for i := 0; i < 4; i++ {
+		errs = append(errs, staging.Push([]float64{float64(i)}, 1))
+	}
+	return
+}
+
+func stagedElemts(staging *core.Staging) (elemts []core.Elemt) {
+	for _, staged := range staging.Drain() {
+		elemts = append(elemts, staged.Elemt)
+	}
+	return
+}
+
+func Test_StagingError(t *testing.T) {
+	var staging, errs = stage(t, core.ErrorOverflow, 0)
+
+	if errs[1] != nil || errs[2] != core.ErrBufferFull || errs[3] != core.ErrBufferFull {
+		t.Error("buffer full errors expected", errs)
+	}
+	if staging.Len() != 2 || staging.Cap() != 2 || staging.Dropped() != 0 {
+		t.Error("full staging expected", staging.Len(), staging.Dropped())
+	}
+}
+
+func Test_StagingTimeout(t *testing.T) {
+	var start = time.Now()
+	var _, errs = stage(t, core.TimeoutOverflow, 10*time.Millisecond)
+
+	if errs[2] != core.ErrBufferFull || time.Now().Sub(start) < 20*time.Millisecond {
+		t.Error("buffer full errors after timeout expected", errs)
+	}
+}
+
+func Test_StagingDropNewest(t *testing.T) {
+	var staging, errs = stage(t, core.DropNewestOverflow, 0)
+
+	if errs[2] != nil || errs[3] != nil || staging.Dropped() != 2 {
+		t.Error("dropped elements expected", errs, staging.Dropped())
+	}
+	if elemts := stagedElemts(staging); !reflect.DeepEqual(elemts, []core.Elemt{[]float64{0}, []float64{1}}) {
+		t.Error("oldest elements expected", elemts)
+	}
+}
+
+func Test_StagingDropOldest(t *testing.T) {
+	var staging, errs = stage(t, core.DropOldestOverflow, 0)
+
+	if errs[2] != nil || errs[3] != nil || staging.Dropped() != 2 {
+		t.Error("dropped elements expected", errs, staging.Dropped())
+	}
+	if elemts := stagedElemts(staging); !reflect.DeepEqual(elemts, []core.Elemt{[]float64{2}, []float64{3}}) {
+		t.Error("newest elements expected", elemts)
+	}
+}
+
+func Test_StagingBlock(t *testing.T) {
+	var staging = core.NewStaging(1, core.DefaultOverflow, 0)
+	var pushed = make(chan error)
+	_ = staging.Push([]float64{0}, 1)
+
+	go func() { pushed <- staging.Push([]float64{1}, 1) }()
+
+	select {
+	case <-pushed:
+		t.Error("blocked push expected")
+	case <-time.After(10 * time.Millisecond):
+	}
+	staging.Next()
+	if err := <-pushed; err != nil {
+		t.Error("no error expected", err)
+	}
+}
+
+func Test_StagingDeleteBlocked(t *testing.T) {
+	var staging = core.NewStaging(4, core.BlockOverflow, 0)
+	for i := 0; i < 4; i++ {
+		_ = staging.Push([]float64{float64(i)}, 1)
+	}
+	var pushed = make(chan error)
+
+	go func() { pushed <- staging.Push([]float64{4}, 1) }()
+	time.Sleep(10 * time.Millisecond)
+	var deleted = staging.Delete(func(elemt core.Elemt) bool { return int(elemt.([]float64)[0])%2 == 1 })
+
+	if err := <-pushed; err != nil {
+		t.Error("no error expected", err)
+	}
+	if len(deleted) != 2 || staging.Dropped() != 0 {
+		t.Error("2 deleted and no dropped elements expected", len(deleted), staging.Dropped())
+	}
+	var expected = []core.Elemt{[]float64{0}, []float64{2}, []float64{4}}
+	if elemts := stagedElemts(staging); !reflect.DeepEqual(expected, elemts) {
+		t.Error("remaining elements before the blocked push expected", elemts)
+	}
+}
+
+func Test_StagingConf(t *testing.T) {
+	var conf = core.CtrlConf{Overflow: core.TimeoutOverflow}
+
+	if conf.Verify() == nil {
+		t.Error("timeout error expected")
+	}
+	if staging := conf.NewStaging(); staging.Cap() != 2000 {
+		t.Error("default capacity expected", staging.Cap())
+	}
+}
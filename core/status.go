@@ -1,5 +1,10 @@
 package core
 
+import (
+	"context"
+	"errors"
+)
+
 // ClustStatus integer type
 type ClustStatus int64
 
@@ -24,13 +29,36 @@ func (clustStatus ClustStatus) String() string {
 	return names[int(clustStatus)]
 }
 
+// Reason explains why an algorithm stopped running
+type Reason int
+
+// Reason const values
+const (
+	NoReason  Reason = iota // the algorithm has not stopped running
+	Converged               // a finishing condition is reached
+	Stopped                 // stopped by the user or a canceled context
+	TimedOut                // interrupted by the timeout or a context deadline
+	Failed                  // an iteration or the initialization failed
+	Exhausted               // the iterations given by Iter and IterPerData are done
+)
+
+var reasons = []string{
+	"", "Converged", "Stopped", "TimedOut", "Failed", "Exhausted",
+}
+
+// String display value message
+func (reason Reason) String() string {
+	return reasons[int(reason)]
+}
+
 // StatusNotifier for being notified by Online clustering change status
 type StatusNotifier = func(OnlineClust, OCStatus)
 
-// OCStatus describes Online Clustering status with ClustStatus and error
+// OCStatus describes Online Clustering status with ClustStatus, error and the reason why the algorithm stopped running
 type OCStatus struct {
-	Value ClustStatus
-	Error error
+	Value  ClustStatus
+	Error  error
+	Reason Reason
 }
 
 // Alive check if status is running without error
@@ -48,10 +76,38 @@ func NewOCStatus(status ClustStatus) OCStatus {
 	return OCStatus{Value: status}
 }
 
-// NewOCStatusError returns new errored ocstatus
+// Terminated check if the algorithm stopped running for a reason
+func (status OCStatus) Terminated() bool {
+	return status.Reason != NoReason
+}
+
+// NewOCStatusError returns new finished ocstatus with the reason given by the error.
+// A nil error means the algorithm is stopped
 func NewOCStatusError(err error) OCStatus {
 	return OCStatus{
-		Value: Finished,
-		Error: err,
+		Value:  Finished,
+		Error:  err,
+		Reason: errorReason(err),
+	}
+}
+
+// NewOCStatusReason returns new ocstatus with specific cluststatus and reason
+func NewOCStatusReason(status ClustStatus, reason Reason) OCStatus {
+	return OCStatus{
+		Value:  status,
+		Reason: reason,
+	}
+}
+
+// errorReason returns the reason of an interruption error
+func errorReason(err error) (reason Reason) {
+	switch {
+	case err == nil || errors.Is(err, context.Canceled):
+		reason = Stopped
+	case errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded):
+		reason = TimedOut
+	default:
+		reason = Failed
 	}
+	return
 }
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// Step synchronously runs exactly n impl iterations in the caller go routine, without starting the run go routine.
+// The algorithm must be ready or idle. An iteration error stops the steps and is returned as an IterationError,
+// without changing the algorithm status. Returns the resulting centroids and runtime figures
+func (algo *Algo) Step(n int) (centroids Clust, figures RuntimeFigures, err error) {
+	algo.ctrlMutex.Lock()
+	defer algo.ctrlMutex.Unlock()
+	var status = algo.Status()
+	if err = algo.closedError(); err == nil {
+		switch status.Value {
+		case Ready, Idle:
+		case Running:
+			err = ErrRunning
+		case Initializing:
+			err = ErrInitializing
+		default:
+			err = ErrNotAlive
+		}
+	}
+	var start = time.Now()
+	for i := 0; i < n && err == nil; i++ {
+		err = algo.step(status, start)
+	}
+	if n > 0 && status.Value == Ready { // while idle, the run go routine already counts the elapsed time
+		algo.modelMutex.Lock()
+		algo.duration += time.Now().Sub(start)
+		algo.modelMutex.Unlock()
+	}
+	var view = algo.View()
+	return view.Centroids, view.RuntimeFigures, err
+}
+
+// step runs one impl iteration and saves its result
+func (algo *Algo) step(status OCStatus, start time.Time) (err error) {
+	defer func() {
+		if recovery := recover(); recovery != nil {
+			err = algo.iterationError(panicError(recovery), status, debug.Stack())
+		}
+	}()
+	var centroids, runtimeFigures, iterErr = algo.impl.Iterate(
+		NewSimpleOCModel(algo.Conf(), algo.Space(), status, algo.RuntimeFigures(), algo.Centroids()),
+	)
+	if iterErr != nil {
+		return algo.iterationError(iterErr, status, nil)
+	}
+	if centroids != nil {
+		algo.modelMutex.Lock()
+		algo.iterations++
+		algo.saveIterContext(centroids, runtimeFigures, time.Now().Sub(start))
+		algo.modelMutex.Unlock()
+		algo.signal()
+		algo.publishIteration(status)
+	}
+	return
+}
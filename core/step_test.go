@@ -0,0 +1,114 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+func Test_Step(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{}, 3)
+
+	var _, _, err = algo.Step(1)
+
+	if err != core.ErrNotAlive {
+		t.Error("not alive error expected", err)
+	}
+
+	err = algo.Init()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var centroids, figures, stepErr = algo.Step(3)
+
+	if stepErr != nil {
+		t.Error("no error expected", stepErr)
+	}
+	if algo.Impl().(*mockImpl).iter != 3 || figures[core.Iterations] != 3 || len(centroids) != 3 {
+		t.Error("3 iterations expected", figures)
+	}
+	if algo.Status().Value != core.Ready {
+		t.Error("ready status expected", algo.Status())
+	}
+}
+
+func Test_StepIdle(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{}, 3)
+
+	var err = algo.Play()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	if _, _, err = algo.Step(1); err != core.ErrRunning {
+		t.Error("running error expected", err)
+	}
+
+	err = algo.Pause()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var iterations = algo.RuntimeFigures()[core.Iterations]
+	var _, figures, stepErr = algo.Step(2)
+
+	if stepErr != nil || figures[core.Iterations] != iterations+2 {
+		t.Error("2 more iterations expected", iterations, figures, stepErr)
+	}
+	if algo.Status().Value != core.Idle {
+		t.Error("idle status expected", algo.Status())
+	}
+	_ = algo.Stop()
+}
+
+// slowImpl is a mock impl whose iterations last a given duration
+type slowImpl struct {
+	*mockImpl
+	iteration time.Duration
+}
+
+func (impl *slowImpl) Iterate(model core.OCModel) (core.Clust, core.RuntimeFigures, error) {
+	time.Sleep(impl.iteration)
+	return impl.mockImpl.Iterate(model)
+}
+
+func Test_StepIdleDuration(t *testing.T) {
+	var algo = core.NewAlgo(
+		&mockConf{},
+		&slowImpl{mockImpl: &mockImpl{clust: make(core.Clust, 3)}, iteration: 20 * time.Millisecond},
+		mockSpace{},
+	)
+	var start = time.Now()
+	_ = algo.Play()
+	_ = algo.Pause()
+
+	var _, _, err = algo.Step(5)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	_ = algo.Stop()
+	if elapsed, duration := time.Now().Sub(start), time.Duration(algo.RuntimeFigures()[core.Duration]); duration > elapsed {
+		t.Error("duration within the elapsed time expected", duration, elapsed)
+	}
+}
+
+func Test_StepError(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{}, 2)
+	_ = algo.Init()
+
+	var _, figures, err = algo.Step(3)
+
+	var iterErr *core.IterationError
+	if !errors.As(err, &iterErr) || !errors.Is(err, errIter) || iterErr.Iteration != 1 {
+		t.Error("iteration error expected", err)
+	}
+	if figures[core.Iterations] != 0 || algo.Status().Value != core.Ready {
+		t.Error("unchanged algorithm expected", figures, algo.Status())
+	}
+}
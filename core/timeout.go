@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -15,12 +16,23 @@ type Timeout interface {
 type timeout struct {
 	duration     time.Duration
 	enabled      bool
+	disabled     chan struct{}   // closed when disabled
+	done         <-chan struct{} // closed when the interrupted run exits, nil if not bound to a run
 	interruption func(error) error
 	mutex        sync.RWMutex
 	finishing    Finishing
 	ocm          OCModel
+	ctx          context.Context
 }
 
+// changeNotifier is implemented by models which signal their status and model changes
+type changeNotifier interface {
+	changes() <-chan struct{}
+}
+
+// step between two finishing checks when the model does not signal its changes
+const pollingStep = 100 * time.Millisecond
+
 func (t *timeout) Enabled() bool {
 	t.mutex.RLock()
 	defer t.mutex.RUnlock()
@@ -29,32 +41,66 @@ func (t *timeout) Enabled() bool {
 
 // InterruptionTimeout process
 func InterruptionTimeout(duration time.Duration, interruption func(error) error) (result Timeout) {
-	result = &timeout{
+	return runTimeout(duration, nil, interruption)
+}
+
+// runTimeout is an interruption timeout which is disabled when done is closed
+func runTimeout(duration time.Duration, done <-chan struct{}, interruption func(error) error) Timeout {
+	var result = &timeout{
 		duration:     duration,
 		enabled:      true,
+		disabled:     make(chan struct{}),
+		done:         done,
 		interruption: interruption,
 	}
-	go result.(*timeout).interrupt()
+	go result.interrupt()
+	return result
+}
+
+// WaitTimeout process. Return ErrTimeout if timed out
+func WaitTimeout(finishing Finishing, duration time.Duration, ocm OCModel) (err error) {
+	var ctx, cancel = timeoutContext(duration)
+	defer cancel()
+	err = WaitContext(ctx, finishing, ocm)
+	if err != nil && err == ctx.Err() {
+		err = ErrTimeout
+	}
 	return
 }
 
-// WaitTimeout process. Return true if timedout
-func WaitTimeout(finishing Finishing, duration time.Duration, ocm OCModel) error {
+// WaitContext process. Return the context error if the context is done before finishing
+func WaitContext(ctx context.Context, finishing Finishing, ocm OCModel) error {
 	if finishing == nil {
 		finishing = NewStatusFinishing(true, Ready, Idle, Finished)
 	}
 	var t = timeout{
-		duration:  duration,
+		ctx:       ctx,
 		ocm:       ocm,
 		finishing: finishing,
 	}
 	return t.wait()
 }
 
+// timeoutContext returns a context with a deadline if duration is positive, otherwise a background context
+func timeoutContext(duration time.Duration) (context.Context, context.CancelFunc) {
+	if duration > 0 {
+		return context.WithTimeout(context.Background(), duration)
+	}
+	return context.Background(), func() {}
+}
+
+// interrupt after the timeout duration unless disabled meanwhile
 func (t *timeout) interrupt() {
-	time.Sleep(t.duration)
-	if t.Enabled() {
-		t.interruption(ErrTimeout)
+	var timer = time.NewTimer(t.duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		if t.Enabled() {
+			t.interruption(ErrTimeout)
+		}
+	case <-t.disabled:
+	case <-t.done:
+		t.Disable()
 	}
 }
 
@@ -62,33 +108,36 @@ func (t *timeout) isFinished() bool {
 	return IsFinished(t.finishing, t.ocm)
 }
 
-func (t *timeout) isTimeout(lastTime time.Time) bool {
-	return t.duration > 0 && time.Now().After(lastTime)
+// changes returns a channel closed at the next model change if the model is a notifier, otherwise after a polling step
+func (t *timeout) changes() <-chan struct{} {
+	if notifier, ok := t.ocm.(changeNotifier); ok {
+		return notifier.changes()
+	}
+	var changes = make(chan struct{})
+	time.AfterFunc(pollingStep, func() { close(changes) })
+	return changes
 }
 
 func (t *timeout) wait() (err error) {
-	var step = 100 * time.Millisecond
-	var elapsedTime = time.Now().Add(t.duration)
-
 	for {
+		var changes = t.changes()
 		if t.isFinished() {
 			break
-		} else if t.isTimeout(elapsedTime) {
-			err = ErrTimeout
-			break
-		} else {
-			time.Sleep(step)
+		}
+		select {
+		case <-t.ctx.Done():
+			return t.ctx.Err()
+		case <-changes:
 		}
 	}
-
-	if err == nil {
-		err = t.ocm.Status().Error
-	}
-	return
+	return t.ocm.Status().Error
 }
 
 func (t *timeout) Disable() {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
+	if t.enabled && t.disabled != nil {
+		close(t.disabled)
+	}
 	t.enabled = false
 }
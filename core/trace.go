@@ -0,0 +1,168 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TraceRecord is the state of an algorithm after an iteration
+type TraceRecord struct {
+	Time       time.Time
+	Iterations int
+	K          int            // number of centroids
+	Loss       float64        // Loss runtime figure, NaN if the impl does not give it
+	PushedData int            // number of pushed data
+	Figures    RuntimeFigures // impl specific runtime figures
+}
+
+// TraceFormat is the encoding of an exported trace
+type TraceFormat int
+
+// TraceFormat const values
+const (
+	CSVTrace       TraceFormat = iota // comma separated values with a header
+	JSONLinesTrace                    // one JSON object per line
+)
+
+// jsonTraceRecord is the JSON content of a trace record
+type jsonTraceRecord struct {
+	Time       time.Time           `json:"time"`
+	Iterations int                 `json:"iterations"`
+	K          int                 `json:"k"`
+	Loss       *float64            `json:"loss,omitempty"`
+	PushedData int                 `json:"pushedData"`
+	Figures    map[string]*float64 `json:"figures,omitempty"`
+}
+
+// Trace returns the recorded iterations, from the oldest to the newest.
+// Iterations are recorded if CtrlConf.Trace is true
+func (algo *Algo) Trace() []TraceRecord {
+	algo.modelMutex.RLock()
+	defer algo.modelMutex.RUnlock()
+	var trace = make([]TraceRecord, len(algo.trace))
+	copy(trace, algo.trace)
+	return trace
+}
+
+// ExportTrace writes the recorded iterations in the given format
+func (algo *Algo) ExportTrace(writer io.Writer, format TraceFormat) error {
+	var trace = algo.Trace()
+	if format == JSONLinesTrace {
+		return exportJSONLinesTrace(writer, trace)
+	}
+	return exportCSVTrace(writer, trace)
+}
+
+// trace the last iteration with the centroids and figures given by the impl and drop the oldest records.
+// The model mutex must be locked by the caller
+func (algo *Algo) traceIteration(centroids Clust, implFigures RuntimeFigures) {
+	var conf = algo.conf.Ctrl()
+	if !conf.Trace {
+		return
+	}
+	var record = TraceRecord{
+		Time:       time.Now(),
+		Iterations: algo.iterations,
+		K:          len(centroids),
+		Loss:       math.NaN(),
+		PushedData: algo.pushedData,
+		Figures:    RuntimeFigures{},
+	}
+	for key, value := range implFigures {
+		if key == Loss {
+			record.Loss = value
+		} else {
+			record.Figures[key] = value
+		}
+	}
+	algo.trace = append(algo.trace, record)
+	if conf.TraceSize > 0 && len(algo.trace) > conf.TraceSize {
+		algo.trace = algo.trace[len(algo.trace)-conf.TraceSize:]
+	}
+}
+
+func exportCSVTrace(writer io.Writer, trace []TraceRecord) error {
+	var keys = figureKeys(trace)
+	var w = csv.NewWriter(writer)
+	var row = append([]string{"time", "iterations", "k", Loss, PushedData}, keys...)
+	var err = w.Write(row)
+	for i := 0; i < len(trace) && err == nil; i++ {
+		var record = trace[i]
+		row = row[:0]
+		row = append(row,
+			record.Time.Format(time.RFC3339Nano),
+			strconv.Itoa(record.Iterations),
+			strconv.Itoa(record.K),
+			formatFigure(record.Loss, true),
+			strconv.Itoa(record.PushedData),
+		)
+		for _, key := range keys {
+			var value, ok = record.Figures[key]
+			row = append(row, formatFigure(value, ok))
+		}
+		err = w.Write(row)
+	}
+	if err == nil {
+		w.Flush()
+		err = w.Error()
+	}
+	return err
+}
+
+func exportJSONLinesTrace(writer io.Writer, trace []TraceRecord) (err error) {
+	var encoder = json.NewEncoder(writer)
+	for i := 0; i < len(trace) && err == nil; i++ {
+		var record = trace[i]
+		var line = jsonTraceRecord{
+			Time:       record.Time,
+			Iterations: record.Iterations,
+			K:          record.K,
+			PushedData: record.PushedData,
+			Loss:       jsonFigure(record.Loss),
+		}
+		if len(record.Figures) > 0 {
+			line.Figures = make(map[string]*float64, len(record.Figures))
+			for key, value := range record.Figures {
+				line.Figures[key] = jsonFigure(value)
+			}
+		}
+		err = encoder.Encode(line)
+	}
+	return
+}
+
+// jsonFigure returns a JSON figure value, nil if it is not finite since JSON does not support NaN and infinity
+func jsonFigure(value float64) *float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	return &value
+}
+
+// figureKeys returns the sorted impl figure names of a trace
+func figureKeys(trace []TraceRecord) (keys []string) {
+	var found = map[string]bool{}
+	for _, record := range trace {
+		for key := range record.Figures {
+			if !found[key] {
+				found[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
+// formatFigure returns a CSV figure value, empty if it is unknown
+func formatFigure(value float64, ok bool) string {
+	if !ok || math.IsNaN(value) {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,143 @@
+package core_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+func Test_Trace(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5, Trace: true, TraceSize: 3}, 3)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var trace = algo.Trace()
+	if len(trace) != 3 {
+		t.Error("3 records expected", len(trace))
+	}
+	for i, record := range trace {
+		if record.Iterations != i+3 || record.K != 3 || !math.IsNaN(record.Loss) {
+			t.Error("wrong record", record)
+		}
+	}
+	if trace[1].Figures["iter"] != 4 || len(trace[0].Figures) != 0 {
+		t.Error("impl figures expected", trace)
+	}
+}
+
+func Test_TraceDisabled(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 3)
+
+	_ = algo.Batch()
+
+	if trace := algo.Trace(); len(trace) != 0 {
+		t.Error("no record expected", trace)
+	}
+}
+
+func Test_ExportTrace(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 4, Trace: true}, 3)
+	_ = algo.Batch()
+
+	var buffer bytes.Buffer
+	var err = algo.ExportTrace(&buffer, core.CSVTrace)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	rows, err := csv.NewReader(&buffer).ReadAll()
+
+	if err != nil || len(rows) != 5 {
+		t.Error("header and 4 rows expected", rows, err)
+	} else {
+		var expected = []string{"time", "iterations", "k", core.Loss, core.PushedData, "iter"}
+		for i := range expected {
+			if rows[0][i] != expected[i] {
+				t.Error("wrong header", rows[0])
+			}
+		}
+		if rows[2][5] != "2" || rows[1][5] != "" || rows[1][3] != "" {
+			t.Error("wrong rows", rows)
+		}
+	}
+
+	buffer.Reset()
+	err = algo.ExportTrace(&buffer, core.JSONLinesTrace)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var scanner = bufio.NewScanner(&buffer)
+	var lines int
+	for scanner.Scan() {
+		var record map[string]interface{}
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Error("json line expected", err)
+		}
+		if _, ok := record["loss"]; ok {
+			t.Error("no loss expected", record)
+		}
+		lines++
+	}
+	if lines != 4 {
+		t.Error("4 lines expected", lines)
+	}
+}
+
+// infiniteImpl is a mock impl with an infinite runtime figure
+type infiniteImpl struct {
+	*mockImpl
+}
+
+func (impl *infiniteImpl) Iterate(model core.OCModel) (clust core.Clust, _ core.RuntimeFigures, err error) {
+	clust, _, err = impl.mockImpl.Iterate(model)
+	return clust, core.RuntimeFigures{"rho": math.Inf(1), "iter": float64(impl.iter)}, err
+}
+
+func Test_ExportTraceInfiniteFigure(t *testing.T) {
+	var algo = core.NewAlgo(
+		&mockConf{CtrlConf: core.CtrlConf{Iter: 2, Trace: true}},
+		&infiniteImpl{&mockImpl{clust: make(core.Clust, 3)}},
+		mockSpace{},
+	)
+	_ = algo.Batch()
+
+	var buffer bytes.Buffer
+	var err = algo.ExportTrace(&buffer, core.JSONLinesTrace)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var scanner = bufio.NewScanner(&buffer)
+	var lines int
+	for scanner.Scan() {
+		var record struct {
+			Figures map[string]*float64 `json:"figures"`
+		}
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Error("json line expected", err)
+		}
+		if rho, ok := record.Figures["rho"]; !ok || rho != nil {
+			t.Error("null rho expected", record.Figures)
+		}
+		if iter := record.Figures["iter"]; iter == nil || *iter != float64(lines+1) {
+			t.Error("finite iter expected", record.Figures)
+		}
+		lines++
+	}
+	if lines != 2 {
+		t.Error("2 lines expected", lines)
+	}
+}
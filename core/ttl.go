@@ -0,0 +1,137 @@
+package core
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// OldestAge is the age in seconds of the oldest data of a time-to-live buffer
+const OldestAge = "oldestAge"
+
+// NewestAge is the age in seconds of the newest data of a time-to-live buffer
+const NewestAge = "newestAge"
+
+// AgeCounter is implemented by impls which timestamp buffered data.
+// The ages of the oldest and newest data are given in the runtime figures if timed is true
+type AgeCounter interface {
+	Ages() (oldest time.Duration, newest time.Duration, timed bool)
+}
+
+// NewTimedDataBuffer creates a buffer with weighted data which evicts data pushed for more than ttl,
+// each time staged data are applied. Initial data are timestamped at creation
+func NewTimedDataBuffer(data []Elemt, weights []float64, ttl time.Duration, staging *Staging) Buffer {
+	if weights == nil {
+		weights = UnitWeights(len(data))
+	}
+	var strategy = &ttlStrategy{ttl: ttl, times: make([]int64, len(data))}
+	var now = time.Now().UnixNano()
+	for i := range strategy.times {
+		strategy.times[i] = now
+	}
+	strategy.publish()
+	var db = DataBuffer{
+		staging:  staging,
+		data:     make([]Elemt, len(data)),
+		weights:  make([]float64, len(data)),
+		strategy: strategy,
+	}
+	copy(db.data, data)
+	copy(db.weights, weights)
+	return &db
+}
+
+// NewBuffer creates a time-to-live buffer if ttl > 0, otherwise a fixed size buffer if size > 0 or an infinite buffer
+func NewBuffer(data []Elemt, weights []float64, size int, ttl time.Duration, staging *Staging) Buffer {
+	if ttl > 0 {
+		return NewTimedDataBuffer(data, weights, ttl, staging)
+	}
+	return NewStagedDataBuffer(data, weights, size, staging)
+}
+
+// VerifyBuffer checks the buffer size and time-to-live of a configuration
+func VerifyBuffer(size int, ttl time.Duration) (err error) {
+	if ttl < 0 {
+		err = errors.New("TTL must be greater or equal than 0")
+	} else if ttl > 0 && size > 0 {
+		err = errors.New("FrameSize and TTL can not be both given")
+	}
+	return
+}
+
+// Time-to-live buffer
+type ttlStrategy struct {
+	ttl    time.Duration
+	times  []int64 // push time of each data, in nanoseconds since the Unix epoch
+	oldest int64   // push time of the oldest data, read atomically
+	newest int64   // push time of the newest data, read atomically
+}
+
+// push data with its push time. Times are kept ordered for eviction,
+// thus data staged by concurrent pushes are not older than previous data
+func (s *ttlStrategy) push(data []Elemt, weights []float64, elemt Elemt, weight float64, at int64) ([]Elemt, []float64) {
+	if n := len(s.times); n > 0 && at < s.times[n-1] {
+		at = s.times[n-1]
+	}
+	s.times = append(s.times, at)
+	s.publish()
+	return append(data, elemt), append(weights, weight)
+}
+
+func (s *ttlStrategy) delete(data []Elemt, weights []float64, predicate func(Elemt) bool) ([]Elemt, []float64, []Elemt, []float64) {
+	var removed = make([]bool, len(data))
+	var n = 0
+	for i, elemt := range data {
+		removed[i] = predicate(elemt)
+		if !removed[i] {
+			s.times[n] = s.times[i]
+			n++
+		}
+	}
+	s.times = s.times[:n]
+	s.publish()
+	return filterData(data, weights, func(i int) bool { return removed[i] })
+}
+
+// evict data pushed before the time-to-live window
+func (s *ttlStrategy) evict(data []Elemt, weights []float64) ([]Elemt, []float64) {
+	var limit = time.Now().Add(-s.ttl).UnixNano()
+	var expired = 0
+	for expired < len(s.times) && s.times[expired] < limit {
+		expired++
+	}
+	if expired > 0 {
+		var n = copy(s.times, s.times[expired:])
+		s.times = s.times[:n]
+		copy(data, data[expired:])
+		copy(weights, weights[expired:])
+		for i := n; i < len(data); i++ { // release evicted data
+			data[i] = nil
+		}
+		data, weights = data[:n], weights[:n]
+		s.publish()
+	}
+	return data, weights
+}
+
+// publish the push times of the oldest and newest data, 0 if the buffer is empty
+func (s *ttlStrategy) publish() {
+	var oldest, newest int64
+	if len(s.times) > 0 {
+		oldest, newest = s.times[0], s.times[len(s.times)-1]
+	}
+	atomic.StoreInt64(&s.oldest, oldest)
+	atomic.StoreInt64(&s.newest, newest)
+}
+
+// ages of the oldest and newest data, 0 if the buffer is empty
+func (s *ttlStrategy) ages() (oldest time.Duration, newest time.Duration) {
+	var now = time.Now().UnixNano()
+	if first := atomic.LoadInt64(&s.oldest); first > 0 {
+		oldest = time.Duration(now - first)
+	}
+	if last := atomic.LoadInt64(&s.newest); last > 0 {
+		newest = time.Duration(now - last)
+	}
+	return
+}
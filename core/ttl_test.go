@@ -0,0 +1,97 @@
+package core_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+func TestTimedDataBuffer_Apply(t *testing.T) {
+	var ttl = 50 * time.Millisecond
+	var buf = core.NewTimedDataBuffer([]core.Elemt{[]float64{0.}}, nil, ttl, core.NewStaging(10, core.DefaultOverflow, 0))
+
+	_ = buf.Push([]float64{1.}, false)
+	time.Sleep(ttl)
+	_ = buf.PushWeighted([]float64{2.}, 2., false)
+	_ = buf.Push([]float64{3.}, true)
+
+	var oldest, newest, timed = buf.Ages()
+
+	if !timed || oldest < ttl || newest >= ttl {
+		t.Error("oldest data expired expected", oldest, newest, timed)
+	}
+
+	_ = buf.Apply()
+
+	if !reflect.DeepEqual(buf.Data(), []core.Elemt{[]float64{2.}, []float64{3.}}) || !reflect.DeepEqual(buf.Weights(), []float64{2., 1.}) {
+		t.Error("expired data evicted expected", buf.Data(), buf.Weights())
+	}
+	if oldest, _, _ = buf.Ages(); oldest >= ttl {
+		t.Error("recent data expected", oldest)
+	}
+
+	var deleted, _, _ = buf.Delete(func(elemt core.Elemt) bool { return elemt.([]float64)[0] == 3 })
+
+	if len(deleted) != 1 || len(buf.Data()) != 1 {
+		t.Error("deleted data expected", deleted, buf.Data())
+	}
+
+	if _, _, timed = core.NewDataBuffer(nil, 0).Ages(); timed {
+		t.Error("untimed buffer expected")
+	}
+}
+
+func TestTimedDataBuffer_PushDuringIteration(t *testing.T) {
+	var ttl = 50 * time.Millisecond
+	var buf = core.NewTimedDataBuffer(nil, nil, ttl, core.NewStaging(10, core.DefaultOverflow, 0))
+
+	_ = buf.Push([]float64{1.}, true) // staged at the beginning of a long iteration
+	time.Sleep(ttl)
+	_ = buf.Push([]float64{2.}, true) // staged at the end of the iteration
+	_ = buf.Apply()
+
+	if !reflect.DeepEqual(buf.Data(), []core.Elemt{[]float64{2.}}) {
+		t.Error("data expired since its push evicted expected", buf.Data())
+	}
+	if oldest, newest, _ := buf.Ages(); oldest >= ttl || oldest != newest {
+		t.Error("age since push expected", oldest, newest)
+	}
+}
+
+func TestTimedDataBuffer_Snapshot(t *testing.T) {
+	var ttl = 50 * time.Millisecond
+	var buf = core.NewTimedDataBuffer([]core.Elemt{[]float64{0.}}, nil, ttl, core.NewStaging(10, core.DefaultOverflow, 0))
+
+	var b bytes.Buffer
+	if err := buf.Snapshot(gob.NewEncoder(&b)); err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var restored = core.NewDataBuffer(nil, 0)
+	if err := restored.Restore(gob.NewDecoder(&b)); err != nil {
+		t.Error("no error expected", err)
+	}
+	if _, _, timed := restored.Ages(); !timed || !reflect.DeepEqual(restored.Data(), buf.Data()) {
+		t.Error("timed buffer expected", restored.Data())
+	}
+
+	time.Sleep(ttl)
+	_ = restored.Apply()
+
+	if len(restored.Data()) != 0 {
+		t.Error("expired data evicted expected", restored.Data())
+	}
+}
+
+func TestVerifyBuffer(t *testing.T) {
+	if core.VerifyBuffer(10, 0) != nil || core.VerifyBuffer(0, time.Hour) != nil {
+		t.Error("no error expected")
+	}
+	if core.VerifyBuffer(0, -time.Hour) == nil || core.VerifyBuffer(10, time.Hour) == nil {
+		t.Error("error expected")
+	}
+}
@@ -7,6 +7,10 @@ type PartitionProcess = func(start, end, rank int)
 
 // Par runs a function in parallel over data partitions given data size and degree of parallelism
 func Par(process func(int, int, int), size int, degree int) {
+	if degree == 1 { // no need for go routines
+		process(0, size, 0)
+		return
+	}
 	var wg = &sync.WaitGroup{}
 	var offset = size / degree
 	var remainder = size % degree
@@ -0,0 +1,65 @@
+package core
+
+// ModelView is an immutable view of the algorithm model, published after each model or status change.
+// Its centroids and runtime figures are copies which must not be modified
+type ModelView struct {
+	Centroids      Clust
+	RuntimeFigures RuntimeFigures
+	Version        int
+	Status         OCStatus
+	Space          Space
+}
+
+// View returns the last published model view, without locking
+func (algo *Algo) View() ModelView {
+	var view = *algo.loadView()
+	view.RuntimeFigures = copyFigures(view.RuntimeFigures)
+	return view
+}
+
+// loadView returns the last published model view, shared by all readers
+func (algo *Algo) loadView() *ModelView {
+	return algo.view.Load().(*ModelView)
+}
+
+// updateView publishes a copy of the current view updated by a function
+func (algo *Algo) updateView(update func(*ModelView)) {
+	algo.viewMutex.Lock()
+	defer algo.viewMutex.Unlock()
+	var view = *algo.loadView()
+	update(&view)
+	algo.view.Store(&view)
+}
+
+// publishModel publishes the current centroids, runtime figures, version and space.
+// The model mutex must be locked by the caller
+func (algo *Algo) publishModel() {
+	var centroids Clust
+	if algo.centroids != nil {
+		centroids = make(Clust, len(algo.centroids))
+		copy(centroids, algo.centroids)
+	}
+	var figures = copyFigures(algo.runtimeFigures)
+	algo.updateView(func(view *ModelView) {
+		view.Centroids = centroids
+		view.RuntimeFigures = figures
+		view.Version = algo.version
+		view.Space = algo.space
+	})
+}
+
+// publishStatus publishes a new status. The status mutex must be locked by the caller
+func (algo *Algo) publishStatus(status OCStatus) {
+	algo.updateView(func(view *ModelView) {
+		view.Status = status
+	})
+}
+
+// copyFigures returns a copy of runtime figures
+func copyFigures(figures RuntimeFigures) (copied RuntimeFigures) {
+	copied = make(RuntimeFigures, len(figures))
+	for key, value := range figures {
+		copied[key] = value
+	}
+	return
+}
@@ -0,0 +1,59 @@
+package core_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+func Test_View(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 3)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	var view = algo.View()
+	if !reflect.DeepEqual(view.Centroids, algo.Centroids()) || view.Version != algo.Version() {
+		t.Error("current model expected", view)
+	}
+	if view.Status != algo.Status() || view.RuntimeFigures[core.Iterations] != 5 {
+		t.Error("current status and figures expected", view)
+	}
+
+	view.RuntimeFigures[core.Iterations] = 0
+	algo.RuntimeFigures()[core.Iterations] = 0
+
+	if algo.RuntimeFigures()[core.Iterations] != 5 || algo.View().RuntimeFigures[core.Iterations] != 5 {
+		t.Error("copied figures expected")
+	}
+}
+
+func Test_PredictWhileRunning(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 1000}, 10)
+	var wg sync.WaitGroup
+
+	var err = algo.Play()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				var view = algo.View()
+				if view.RuntimeFigures[core.Iterations] < 0 || len(view.Centroids) != 10 {
+					t.Error("consistent view expected", view)
+				}
+				algo.Predict(nil)
+			}
+		}()
+	}
+	wg.Wait()
+	_ = algo.Stop()
+}
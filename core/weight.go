@@ -0,0 +1,60 @@
+package core
+
+import "math"
+
+// precision of non integer weights given to Space.Combine
+const combinePrecision = 1 << 20
+
+// UnitWeights returns n weights equal to 1
+func UnitWeights(n int) (weights []float64) {
+	weights = make([]float64, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return
+}
+
+// WeightedCombine combines two elements with real weights.
+// If the space is a FloatCombiner, weights are given as is to the space.
+// Otherwise integer weights are given as is, and non-integer weights are scaled and rounded in order to keep their ratio
+func WeightedCombine(space Space, elemt1 Elemt, weight1 float64, elemt2 Elemt, weight2 float64) Elemt {
+	if combiner, ok := space.(FloatCombiner); ok {
+		return combiner.CombineFloat(elemt1, weight1, elemt2, weight2)
+	}
+	if weight1 != math.Trunc(weight1) || weight2 != math.Trunc(weight2) {
+		var scale = combinePrecision / (weight1 + weight2)
+		weight1 = math.Round(weight1 * scale)
+		weight2 = combinePrecision - weight1
+	}
+	return space.Combine(elemt1, int(weight1), elemt2, int(weight2))
+}
+
+// weightAt returns the weight of the i-th element, 1 if weights is nil
+func weightAt(weights []float64, i int) float64 {
+	if weights == nil {
+		return 1
+	}
+	return weights[i]
+}
+
+// validWeight returns true if a weight is a positive finite number
+func validWeight(weight float64) bool {
+	return weight > 0 && !math.IsInf(weight, 1)
+}
+
+// weightsSlice returns the weights of a data partition, nil if weights is nil
+func weightsSlice(weights []float64, start, end int) []float64 {
+	if weights == nil {
+		return nil
+	}
+	return weights[start:end]
+}
+
+// toCards rounds total weights to cardinalities
+func toCards(totals []float64) (cards []int) {
+	cards = make([]int, len(totals))
+	for i, total := range totals {
+		cards[i] = int(math.Round(total))
+	}
+	return
+}
@@ -20,6 +20,40 @@ func NewSpace() Space {
 	}
 }
 
+// registered space name
+const spaceName = "cosinus"
+
+func init() {
+	core.RegisterSpace(spaceName, func(func(core.SpaceConf) error) (core.Space, error) {
+		return NewSpace(), nil
+	}, []float64{})
+}
+
+// Identity returns the registered space name
+func (space Space) Identity() (string, core.SpaceConf) {
+	return spaceName, nil
+}
+
+// EncodeElemt encodes a vector as little endian float64 values
+func (space Space) EncodeElemt(elemt core.Elemt) ([]byte, error) {
+	return space.vspace.EncodeElemt(elemt)
+}
+
+// DecodeElemt decodes a vector encoded by EncodeElemt
+func (space Space) DecodeElemt(data []byte) (core.Elemt, error) {
+	return space.vspace.DecodeElemt(data)
+}
+
+// EncodeJSONElemt encodes a vector as a JSON array
+func (space Space) EncodeJSONElemt(elemt core.Elemt) ([]byte, error) {
+	return space.vspace.EncodeJSONElemt(elemt)
+}
+
+// DecodeJSONElemt decodes a vector encoded by EncodeJSONElemt
+func (space Space) DecodeJSONElemt(data []byte) (core.Elemt, error) {
+	return space.vspace.DecodeJSONElemt(data)
+}
+
 // Dist returns the cosinus distance between elemt1 and elemt2
 func (space Space) Dist(elemt1, elemt2 core.Elemt) float64 {
 	var v1 = elemt1.([]float64)
@@ -37,11 +71,31 @@ func (space Space) Combine(elemt1 core.Elemt, weight1 int, elemt2 core.Elemt, we
 	return space.vspace.Combine(elemt1, weight1, elemt2, weight2)
 }
 
+// CombineFloat returns the weighted average of elemt1 and elemt2 with real weights
+func (space Space) CombineFloat(elemt1 core.Elemt, weight1 float64, elemt2 core.Elemt, weight2 float64) core.Elemt {
+	return space.vspace.CombineFloat(elemt1, weight1, elemt2, weight2)
+}
+
 // PointCombine return combination of points
 func (space Space) PointCombine(point1 []float64, weight1 int, point2 []float64, weight2 int) []float64 {
 	return space.vspace.PointCombine(point1, weight1, point2, weight2)
 }
 
+// PointCombineFloat return combination of points with real weights
+func (space Space) PointCombineFloat(point1 []float64, weight1 float64, point2 []float64, weight2 float64) []float64 {
+	return space.vspace.PointCombineFloat(point1, weight1, point2, weight2)
+}
+
+// Uncombine removes elemt2 with its weight from the weighted average elemt1
+func (space Space) Uncombine(elemt1 core.Elemt, weight1 float64, elemt2 core.Elemt, weight2 float64) core.Elemt {
+	return space.vspace.Uncombine(elemt1, weight1, elemt2, weight2)
+}
+
+// PointUncombine return the combination of points from which point2 has been removed
+func (space Space) PointUncombine(point1 []float64, weight1 float64, point2 []float64, weight2 float64) []float64 {
+	return space.vspace.PointUncombine(point1, weight1, point2, weight2)
+}
+
 // Copy returns a copy of the given elements
 func (space Space) Copy(elemt core.Elemt) core.Elemt {
 	return space.vspace.Copy(elemt)
@@ -72,3 +72,26 @@ func TestSpace_Copy(t *testing.T) {
 		t.Error("result should be [1., 1.] got", v2)
 	}
 }
+
+func Test_Codec(t *testing.T) {
+	var space core.ElemtCodec = cosinus.NewSpace()
+	var point = []float64{1., 2.}
+
+	var data, err = space.EncodeElemt(point)
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	decoded, err := space.DecodeElemt(data)
+	if err != nil || decoded.([]float64)[1] != 2. {
+		t.Error("same point expected", decoded, err)
+	}
+
+	data, err = space.EncodeJSONElemt(point)
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	decoded, err = space.DecodeJSONElemt(data)
+	if err != nil || decoded.([]float64)[1] != 2. {
+		t.Error("same point expected", decoded, err)
+	}
+}
@@ -0,0 +1,74 @@
+package dtw
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+// EncodeElemt encodes a series as its number of points followed by each point length and little endian float64 values
+func (space Space) EncodeElemt(elemt core.Elemt) (data []byte, err error) {
+	var series, ok = elemt.([][]float64)
+	if !ok {
+		return nil, core.ErrElemtType
+	}
+	var varint = make([]byte, binary.MaxVarintLen64)
+	data = append(data, varint[:binary.PutUvarint(varint, uint64(len(series)))]...)
+	for _, point := range series {
+		data = append(data, varint[:binary.PutUvarint(varint, uint64(len(point)))]...)
+		for _, value := range point {
+			var bits = make([]byte, 8)
+			binary.LittleEndian.PutUint64(bits, math.Float64bits(value))
+			data = append(data, bits...)
+		}
+	}
+	return
+}
+
+// DecodeElemt decodes a series encoded by EncodeElemt
+func (space Space) DecodeElemt(data []byte) (elemt core.Elemt, err error) {
+	var size, n = binary.Uvarint(data)
+	if n <= 0 || size > uint64(len(data)) {
+		return nil, core.ErrElemtEncoding
+	}
+	data = data[n:]
+	var series = make([][]float64, size)
+	for i := range series {
+		var dim, n = binary.Uvarint(data)
+		if n <= 0 || dim > uint64(len(data)-n)/8 {
+			return nil, core.ErrElemtEncoding
+		}
+		data = data[n:]
+		series[i] = make([]float64, dim)
+		for j := range series[i] {
+			series[i][j] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*j:]))
+		}
+		data = data[8*dim:]
+	}
+	if len(data) > 0 {
+		return nil, core.ErrElemtEncoding
+	}
+	return series, nil
+}
+
+// EncodeJSONElemt encodes a series as a JSON array of arrays
+func (space Space) EncodeJSONElemt(elemt core.Elemt) (data []byte, err error) {
+	var series, ok = elemt.([][]float64)
+	if ok {
+		data, err = json.Marshal(series)
+	} else {
+		err = core.ErrElemtType
+	}
+	return
+}
+
+// DecodeJSONElemt decodes a series encoded by EncodeJSONElemt
+func (space Space) DecodeJSONElemt(data []byte) (elemt core.Elemt, err error) {
+	var series [][]float64
+	if err = json.Unmarshal(data, &series); err == nil {
+		elemt = series
+	}
+	return
+}
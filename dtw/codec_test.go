@@ -0,0 +1,39 @@
+package dtw_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+	"github.com/wearelumenai/distclus/dtw"
+)
+
+func TestSpace_Codec(t *testing.T) {
+	var space core.ElemtCodec = dtw.NewSpace(conf)
+	var series = [][]float64{{1.5, -2.}, {3.25}, {}}
+
+	var data, err = space.EncodeElemt(series)
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	decoded, err := space.DecodeElemt(data)
+	if err != nil || !reflect.DeepEqual(decoded, series) {
+		t.Error("same series expected", decoded, err)
+	}
+
+	data, err = space.EncodeJSONElemt(series)
+	if err != nil || string(data) != "[[1.5,-2],[3.25],[]]" {
+		t.Error("JSON array expected", string(data), err)
+	}
+	decoded, err = space.DecodeJSONElemt(data)
+	if err != nil || !reflect.DeepEqual(decoded, series) {
+		t.Error("same series expected", decoded, err)
+	}
+
+	if _, err = space.EncodeElemt([]float64{1.}); err != core.ErrElemtType {
+		t.Error("type error expected", err)
+	}
+	if _, err = space.DecodeElemt([]byte{2, 1, 0}); err != core.ErrElemtEncoding {
+		t.Error("encoding error expected", err)
+	}
+}
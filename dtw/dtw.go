@@ -50,6 +50,18 @@ func (dtw DTW) DBA(w1, w2 int) [][]float64 {
 	return dtw.interpolate(dba, idx, w1+w2)
 }
 
+// DBAFloat computes the average between series with the given real weights
+func (dtw DTW) DBAFloat(w1, w2 float64) [][]float64 {
+	var dba = make([][]float64, len(dtw.path))
+	var idx = make([]float64, len(dtw.path))
+	for i := range dtw.path {
+		var ends = dtw.path[len(dtw.path)-1-i]
+		dba[i] = combinePoints(dtw.space, dtw.s1[ends.End0], w1, dtw.s2[ends.End1], w2)
+		idx[i] = float64(ends.End0)*w1 + float64(ends.End1)*w2
+	}
+	return InterpolateFloat(dba, idx, w1+w2, dtw.space)
+}
+
 func (dtw *DTW) interpolate(ts [][]float64, idx []int, shrinkFactor int) [][]float64 {
 	return Interpolate(ts, idx, shrinkFactor, dtw.space)
 }
@@ -126,3 +126,9 @@ func Test_DTWDBAWindow3(t *testing.T) {
 	var dist = dtw.NewDTWWindow(s1, s2, space, 1)
 	AssertSeriesAlmostEqual(t, dbaw1, dist.DBA(2, 1))
 }
+
+func Test_DTWDBAFloat(t *testing.T) {
+	var dist = dtw.NewDTW(s1, s2, space)
+	AssertSeriesAlmostEqual(t, dbaw, dist.DBAFloat(1, 2))
+	AssertSeriesAlmostEqual(t, dbaw, dist.DBAFloat(.5, 1))
+}
@@ -19,6 +19,25 @@ func Interpolate(s [][]float64, idx []int, shrinkFactor int, space PointSpace) [
 	return result
 }
 
+// InterpolateFloat applies a real shrink factor and reshapes the given series to integral index.
+// The given series has real indexes, given by the idx parameter.
+func InterpolateFloat(s [][]float64, idx []float64, shrinkFactor float64, space PointSpace) [][]float64 {
+	var last = int(idx[len(s)-1]/shrinkFactor) + 1
+	var result = make([][]float64, last)
+	result[0] = s[0]
+	for i, j := 1, 1; i < last; i++ {
+		var x = float64(i) * shrinkFactor
+		for ; j < len(s)-1 && x > idx[j]; j++ {
+		}
+		if idx[j] <= x {
+			result[i] = s[j]
+		} else {
+			result[i] = combinePoints(space, s[j-1], idx[j]-x, s[j], x-idx[j-1])
+		}
+	}
+	return result
+}
+
 // Resize shrinks or extends a series to a new size
 func Resize(s [][]float64, size int, space PointSpace) [][]float64 {
 	var idx = make([]int, len(s))
@@ -32,6 +32,15 @@ func Test_Interpolate(t *testing.T) {
 	AssertSeriesAlmostEqual(t, dba, si1)
 }
 
+func Test_InterpolateFloat(t *testing.T) {
+	var fx = make([]float64, len(ix))
+	for i := range ix {
+		fx[i] = float64(ix[i]) / 2
+	}
+	var si1 = dtw.InterpolateFloat(si, fx, float64(iw)/2, space)
+	AssertSeriesAlmostEqual(t, dba, si1)
+}
+
 func Test_InterpolateId(t *testing.T) {
 	var id = []int{0, 1, 2, 3, 4, 5, 6, 7}
 	var si1 = dtw.Interpolate(si, id, 1, space)
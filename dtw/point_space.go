@@ -9,3 +9,8 @@ type PointSpace interface {
 	PointCombine(point1 []float64, weight1 int, point2 []float64, weight2 int) []float64
 	PointCopy(point []float64) []float64
 }
+
+// combinePoints combines two points with real weights
+func combinePoints(space PointSpace, point1 []float64, weight1 float64, point2 []float64, weight2 float64) []float64 {
+	return core.WeightedCombine(space, point1, weight1, point2, weight2).([]float64)
+}
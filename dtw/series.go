@@ -1,9 +1,41 @@
 package dtw
 
 import (
+	"errors"
+
 	"github.com/wearelumenai/distclus/core"
 )
 
+// registered space name
+const spaceName = "dtw"
+
+func init() {
+	core.RegisterSpace(spaceName, buildSpace, [][]float64{})
+}
+
+// identityConf is the serializable configuration of a dtw space
+type identityConf struct {
+	Window     int    `json:"window"`
+	InnerSpace string `json:"innerSpace"`
+}
+
+// buildSpace creates a space from a serialized configuration
+func buildSpace(decode func(core.SpaceConf) error) (space core.Space, err error) {
+	var conf identityConf
+	var inner core.Space
+	if err = decode(&conf); err == nil {
+		inner, err = core.BuildSpace(conf.InnerSpace, func(core.SpaceConf) error { return nil })
+	}
+	if err == nil {
+		if pointSpace, ok := inner.(PointSpace); ok {
+			space = NewSpace(Conf{Window: conf.Window, InnerSpace: pointSpace})
+		} else {
+			err = errors.New("inner space is not a point space")
+		}
+	}
+	return
+}
+
 // Space for processing vectors of vectors ([][]float64)
 type Space struct {
 	window     int
@@ -18,6 +50,15 @@ func NewSpace(conf Conf) Space {
 	}
 }
 
+// Identity returns the registered space name with the window and the inner space name
+func (space Space) Identity() (string, core.SpaceConf) {
+	var conf = identityConf{Window: space.window}
+	if inner, ok := space.innerSpace.(core.IdentifiedSpace); ok {
+		conf.InnerSpace, _ = inner.Identity()
+	}
+	return spaceName, conf
+}
+
 // Dist computes the DTW distance between the given series
 func (space Space) Dist(elemt1, elemt2 core.Elemt) (sum float64) {
 	var s1, s2 = space.getSeries(elemt1, elemt2)
@@ -32,6 +73,13 @@ func (space Space) Combine(elemt1 core.Elemt, weight1 int, elemt2 core.Elemt, we
 	return dtw.DBA(weight1, weight2)
 }
 
+// CombineFloat computes the DTW based average of the given series with real weights
+func (space Space) CombineFloat(elemt1 core.Elemt, weight1 float64, elemt2 core.Elemt, weight2 float64) core.Elemt {
+	var s1, s2 = space.getSeries(elemt1, elemt2)
+	var dtw = NewDTWWindow(s1, s2, space.innerSpace, space.window)
+	return dtw.DBAFloat(weight1, weight2)
+}
+
 func (space Space) getSeries(elemt1 core.Elemt, elemt2 core.Elemt) ([][]float64, [][]float64) {
 	var e1 = elemt1.([][]float64)
 	var e2 = elemt2.([][]float64)
@@ -1,8 +1,11 @@
 package dtw_test
 
 import (
+	"bytes"
+	"reflect"
 	"testing"
 
+	"github.com/wearelumenai/distclus/core"
 	"github.com/wearelumenai/distclus/dtw"
 )
 
@@ -68,3 +71,31 @@ func TestSpace_CombineWeight(t *testing.T) {
 	var s = space.Combine(s1, 2, s2, 1)
 	AssertSeriesAlmostEqual(t, dbaw1, s.([][]float64))
 }
+
+func TestSpace_Export(t *testing.T) {
+	var series = [][]float64{{0., 1.}, {1., 2.}, {2., 3.}}
+	var predictor = core.NewPredictor(core.Clust{series}, nil, dtw.NewSpace(conf))
+
+	for _, format := range []core.ModelFormat{core.JSONModel, core.BinaryModel} {
+		var b bytes.Buffer
+		var err = predictor.Export(&b, format)
+
+		if err != nil {
+			t.Error("no error expected", err)
+		}
+
+		loaded, err := core.LoadPredictor(&b)
+
+		if err != nil {
+			t.Error("no error expected", err)
+		}
+
+		if !reflect.DeepEqual(loaded.Space(), dtw.NewSpace(conf)) {
+			t.Error("same space expected", loaded.Space())
+		}
+
+		if !reflect.DeepEqual(loaded.Centroids(), predictor.Centroids()) {
+			t.Error("same centroids expected", loaded.Centroids())
+		}
+	}
+}
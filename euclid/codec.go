@@ -0,0 +1,70 @@
+package euclid
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+// EncodeElemt encodes a vector as little endian float64 values
+func (space Space) EncodeElemt(elemt core.Elemt) (data []byte, err error) {
+	var point, ok = elemt.([]float64)
+	if ok {
+		data = EncodePoint(point)
+	} else {
+		err = core.ErrElemtType
+	}
+	return
+}
+
+// DecodeElemt decodes a vector encoded by EncodeElemt
+func (space Space) DecodeElemt(data []byte) (elemt core.Elemt, err error) {
+	var point []float64
+	if point, err = DecodePoint(data); err == nil {
+		elemt = point
+	}
+	return
+}
+
+// EncodeJSONElemt encodes a vector as a JSON array
+func (space Space) EncodeJSONElemt(elemt core.Elemt) (data []byte, err error) {
+	var point, ok = elemt.([]float64)
+	if ok {
+		data, err = json.Marshal(point)
+	} else {
+		err = core.ErrElemtType
+	}
+	return
+}
+
+// DecodeJSONElemt decodes a vector encoded by EncodeJSONElemt
+func (space Space) DecodeJSONElemt(data []byte) (elemt core.Elemt, err error) {
+	var point []float64
+	if err = json.Unmarshal(data, &point); err == nil {
+		elemt = point
+	}
+	return
+}
+
+// EncodePoint encodes a point as little endian float64 values
+func EncodePoint(point []float64) (data []byte) {
+	data = make([]byte, 8*len(point))
+	for i, value := range point {
+		binary.LittleEndian.PutUint64(data[8*i:], math.Float64bits(value))
+	}
+	return
+}
+
+// DecodePoint decodes a point encoded by EncodePoint
+func DecodePoint(data []byte) (point []float64, err error) {
+	if len(data)%8 != 0 {
+		return nil, core.ErrElemtEncoding
+	}
+	point = make([]float64, len(data)/8)
+	for i := range point {
+		point[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	return
+}
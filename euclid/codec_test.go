@@ -0,0 +1,39 @@
+package euclid_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+	"github.com/wearelumenai/distclus/euclid"
+)
+
+func TestSpace_Codec(t *testing.T) {
+	var space core.ElemtCodec = euclid.Space{}
+	var point = []float64{1.5, -2., 3.25}
+
+	var data, err = space.EncodeElemt(point)
+	if err != nil || len(data) != 24 {
+		t.Error("24 bytes expected", data, err)
+	}
+	decoded, err := space.DecodeElemt(data)
+	if err != nil || !reflect.DeepEqual(decoded, point) {
+		t.Error("same point expected", decoded, err)
+	}
+
+	data, err = space.EncodeJSONElemt(point)
+	if err != nil || string(data) != "[1.5,-2,3.25]" {
+		t.Error("JSON array expected", string(data), err)
+	}
+	decoded, err = space.DecodeJSONElemt(data)
+	if err != nil || !reflect.DeepEqual(decoded, point) {
+		t.Error("same point expected", decoded, err)
+	}
+
+	if _, err = space.EncodeElemt("wrong"); err != core.ErrElemtType {
+		t.Error("type error expected", err)
+	}
+	if _, err = space.DecodeElemt([]byte{1, 2, 3}); err != core.ErrElemtEncoding {
+		t.Error("encoding error expected", err)
+	}
+}
@@ -7,6 +7,15 @@ import (
 	"github.com/wearelumenai/distclus/core"
 )
 
+// registered space name
+const spaceName = "euclid"
+
+func init() {
+	core.RegisterSpace(spaceName, func(func(core.SpaceConf) error) (core.Space, error) {
+		return NewSpace(), nil
+	}, []float64{})
+}
+
 // Space for vectors ([]float64)
 type Space struct{}
 
@@ -15,6 +24,11 @@ func NewSpace() Space {
 	return Space{}
 }
 
+// Identity returns the registered space name
+func (space Space) Identity() (string, core.SpaceConf) {
+	return spaceName, nil
+}
+
 // Dist computes euclidean distance between two nodes
 func (space Space) Dist(elemt1, elemt2 core.Elemt) float64 {
 	var e1 = elemt1.([]float64)
@@ -40,11 +54,22 @@ func (space Space) Combine(elemt1 core.Elemt, weight1 int, elemt2 core.Elemt, we
 	return space.PointCombine(e1, weight1, e2, weight2)
 }
 
+// CombineFloat computes combination between two nodes with real weights
+func (space Space) CombineFloat(elemt1 core.Elemt, weight1 float64, elemt2 core.Elemt, weight2 float64) core.Elemt {
+	var e1 = elemt1.([]float64)
+	var e2 = elemt2.([]float64)
+
+	return space.PointCombineFloat(e1, weight1, e2, weight2)
+}
+
 // PointCombine returns combination of points
 func (space Space) PointCombine(point1 []float64, weight1 int, point2 []float64, weight2 int) []float64 {
+	return space.PointCombineFloat(point1, float64(weight1), point2, float64(weight2))
+}
+
+// PointCombineFloat returns combination of points with real weights
+func (space Space) PointCombineFloat(point1 []float64, w1 float64, point2 []float64, w2 float64) []float64 {
 	var dim = len(point1)
-	var w1 = float64(weight1)
-	var w2 = float64(weight2)
 	var t = w1 + w2
 	var result = make([]float64, dim)
 	for i := 0; i < dim; i++ {
@@ -53,6 +78,25 @@ func (space Space) PointCombine(point1 []float64, weight1 int, point2 []float64,
 	return result
 }
 
+// Uncombine removes a node with its weight from a combination
+func (space Space) Uncombine(elemt1 core.Elemt, weight1 float64, elemt2 core.Elemt, weight2 float64) core.Elemt {
+	var e1 = elemt1.([]float64)
+	var e2 = elemt2.([]float64)
+
+	return space.PointUncombine(e1, weight1, e2, weight2)
+}
+
+// PointUncombine returns the combination of points from which point2 has been removed
+func (space Space) PointUncombine(point1 []float64, w1 float64, point2 []float64, w2 float64) []float64 {
+	var dim = len(point1)
+	var t = w1 - w2
+	var result = make([]float64, dim)
+	for i := 0; i < dim; i++ {
+		result[i] = (point1[i]*w1 - point2[i]*w2) / t
+	}
+	return result
+}
+
 // Copy creates a copy of a vector
 func (space Space) Copy(elemt core.Elemt) core.Elemt {
 	var point = elemt.([]float64)
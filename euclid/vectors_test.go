@@ -76,6 +76,26 @@ func TestVectorCombine2_1x0And4_2x1(t *testing.T) {
 	}
 }
 
+func TestVectorCombineFloat2x0_5And4x1_5(t *testing.T) {
+	e1 := []float64{2}
+	e2 := []float64{4}
+	space := euclid.Space{}
+	var e3 = space.CombineFloat(e1, .5, e2, 1.5).([]float64)
+	if e3[0] != 3.5 {
+		t.Errorf("Expected 3.5, got %v", e3)
+	}
+}
+
+func TestVectorUncombine3_5x2And4x1_5(t *testing.T) {
+	e1 := []float64{3.5}
+	e2 := []float64{4}
+	space := euclid.Space{}
+	var e3 = space.Uncombine(e1, 2, e2, 1.5).([]float64)
+	if e3[0] != 2 {
+		t.Errorf("Expected 2, got %v", e3)
+	}
+}
+
 func TestVectorSpace_Copy(t *testing.T) {
 	var e1 = []float64{2, 1}
 	sp := euclid.Space{}
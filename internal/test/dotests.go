@@ -26,6 +26,20 @@ var Vectors = []core.Elemt{
 	[]float64{50, 51.2, 49, 40, 45.2},
 }
 
+// SpreadVectors returns n copies of Vectors, each one slightly shifted
+func SpreadVectors(n int) (data []core.Elemt) {
+	for i := 0; i < n; i++ {
+		for _, vector := range Vectors {
+			var elemt = make([]float64, len(vector.([]float64)))
+			for j, x := range vector.([]float64) {
+				elemt[j] = x + float64((i*7+j)%11)/10
+			}
+			data = append(data, elemt)
+		}
+	}
+	return
+}
+
 // DoTestInitialization Algorithm must be configured with GivenInitializer with 3 centers and 0 iteration
 func DoTestInitialization(t *testing.T, algo core.OnlineClust) {
 	var actual = PushAndInit(algo)
@@ -51,6 +65,26 @@ func DoTestRunSyncGiven(t *testing.T, algo core.OnlineClust) {
 	AssertArrayEqual(t, expected, actual)
 }
 
+// DoTestStep Algorithm must be configured with GivenInitializer with 3 centers
+func DoTestStep(t *testing.T, algo core.OnlineClust) {
+	var _, _, err = algo.Step(1)
+	AssertError(t, err)
+
+	PushAndInit(algo)
+	var centroids, figures, stepErr = algo.Step(3)
+	AssertNoError(t, stepErr)
+	AssertTrue(t, figures[core.Iterations] == 3)
+	AssertTrue(t, algo.Status().Value == core.Ready)
+	AssertCentroids(t, algo.Centroids(), centroids)
+
+	var actual, _ = centroids.MapLabel(Vectors, euclid.Space{})
+	var expected = make([]int, len(Vectors))
+	for i, elemt := range Vectors {
+		_, expected[i], _ = algo.Predict(elemt)
+	}
+	AssertArrayEqual(t, expected, actual)
+}
+
 // DoTestRunSyncPP Algorithm must be configured with PP with 3 centers
 func DoTestRunSyncPP(t *testing.T, algo core.OnlineClust) {
 	var clust = PushAndRunSync(algo)
@@ -898,131 +932,42 @@ func DoTestIterToRun(t *testing.T, algo core.OnlineClust) { // must Iter == 0
 }
 
 // DoTestSetConf test reconfiguration
-/* func DoTestSetConf(t *testing.T, algo *core.Algo) {
-
-	var conf = algo.Conf()
-	conf.Ctrl().Iter = 1000
-
-	// var err = algo.SetConf(conf)
-
-	if err != core.ErrNotRunning {
-		t.Error("not started expected", err)
-	}
-
-	err = algo.Init()
-
-	if err != nil {
-		t.Error("No error expected", err)
-	}
-
-	err = algo.SetConf(algo.Conf())
-
-	if err != core.ErrNotRunning {
-		t.Error("not started expected", err)
-	}
-
-	err = algo.Play()
-
-	if err != nil {
-		t.Error("No error expected", err)
-	}
-
-	err = algo.SetConf(algo.Conf())
-
-	if err != nil {
-		t.Error("reconfiguration expected", err)
-	}
-
-	if algo.Status().Value != core.Running {
-		t.Error("running expected", algo.Status().Value)
-	}
-
-	err = algo.Pause()
-
-	if err != nil {
-		t.Error("not started expected", err)
-	}
-
-	if algo.Status().Value != core.Idle {
-		t.Error("idle expected", algo.Status().Value)
-	}
-
-	err = algo.SetConf(algo.Conf())
-
-	if err != nil {
-		t.Error("reconfiguration expected", err)
-	}
-
-	if algo.Status().Value != core.Idle {
-		t.Error("running expected", algo.Status().Value)
-	}
-
-	err = algo.Play()
-
-	if err != nil {
-		t.Error("running expected", err)
-	}
-
-	err = algo.Wait(nil, 0)
-
-	if err != nil {
-		t.Error("ended expected", err)
-	}
+func DoTestSetConf(t *testing.T, algo *core.Algo) {
+	doTestReconfiguration(t, algo, func() error { return algo.SetConf(algo.Conf()) })
+}
 
-	if algo.Status().Value != core.Finished {
-		t.Error("Finished expected", algo.Status().Value)
-	}
+// DoTestSetSpace test reconfiguration
+func DoTestSetSpace(t *testing.T, algo *core.Algo) {
+	doTestReconfiguration(t, algo, func() error { return algo.SetSpace(algo.Space()) })
+}
 
-	err = algo.SetConf(algo.Conf())
+func doTestReconfiguration(t *testing.T, algo *core.Algo, reconfigure func() error) {
+	var err = reconfigure()
 
 	if err != nil {
 		t.Error("reconfiguration expected", err)
 	}
 
-	if algo.Status().Value != core.Finished {
-		t.Error("Finished expected", algo.Status().Value)
+	if algo.Status().Value != core.Created {
+		t.Error("created expected", algo.Status().Value)
 	}
 
-	err = algo.Stop()
+	err = algo.Init()
 
 	if err != nil {
-		t.Error("not error expected", err)
+		t.Error("No error expected", err)
 	}
 
-	if algo.Status().Value < core.Running {
-		t.Error("not running expected", algo.Status().Value)
-	}
+	var centroids = algo.Centroids()
 
-	err = algo.SetConf(algo.Conf())
+	err = reconfigure()
 
 	if err != nil {
 		t.Error("reconfiguration expected", err)
 	}
 
-	if algo.Status().Value != core.Finished {
-		t.Error("stopped expected", algo.Status().Value)
-	}
-} */
-
-// DoTestSetSpace test reconfiguration
-/* func DoTestSetSpace(t *testing.T, algo *core.Algo) {
-
-	var err = algo.SetSpace(algo.Space())
-
-	if err != core.ErrNotRunning {
-		t.Error("not started expected", err)
-	}
-
-	err = algo.Init()
-
-	if err != nil {
-		t.Error("No error expected", err)
-	}
-
-	err = algo.SetSpace(algo.Space())
-
-	if err != core.ErrNotRunning {
-		t.Error("not started expected", err)
+	if len(algo.Centroids()) != len(centroids) {
+		t.Error("same centroids expected", algo.Centroids())
 	}
 
 	err = algo.Play()
@@ -1031,7 +976,7 @@ func DoTestIterToRun(t *testing.T, algo core.OnlineClust) { // must Iter == 0
 		t.Error("No error expected", err)
 	}
 
-	err = algo.SetSpace(algo.Space())
+	err = reconfigure()
 
 	if err != nil {
 		t.Error("reconfiguration expected", err)
@@ -1047,18 +992,14 @@ func DoTestIterToRun(t *testing.T, algo core.OnlineClust) { // must Iter == 0
 		t.Error("not started expected", err)
 	}
 
-	if algo.Status().Value != core.Idle {
-		t.Error("idle expected", algo.Status().Value)
-	}
-
-	err = algo.SetSpace(algo.Space())
+	err = reconfigure()
 
 	if err != nil {
 		t.Error("reconfiguration expected", err)
 	}
 
 	if algo.Status().Value != core.Idle {
-		t.Error("running expected", algo.Status().Value)
+		t.Error("idle expected", algo.Status().Value)
 	}
 
 	err = algo.Play()
@@ -1067,24 +1008,14 @@ func DoTestIterToRun(t *testing.T, algo core.OnlineClust) { // must Iter == 0
 		t.Error("running expected", err)
 	}
 
-	err = algo.Wait(nil, 0)
-
-	if err != nil {
-		t.Error("ended expected", err)
-	}
-
-	if algo.Status().Value != core.Finished {
-		t.Error("Finished expected", algo.Status().Value)
-	}
-
-	err = algo.SetSpace(algo.Space())
+	err = reconfigure()
 
 	if err != nil {
 		t.Error("reconfiguration expected", err)
 	}
 
-	if algo.Status().Value != core.Finished {
-		t.Error("Finished expected", algo.Status().Value)
+	if algo.Status().Value != core.Running {
+		t.Error("running expected", algo.Status().Value)
 	}
 
 	err = algo.Stop()
@@ -1093,11 +1024,7 @@ func DoTestIterToRun(t *testing.T, algo core.OnlineClust) { // must Iter == 0
 		t.Error("not error expected", err)
 	}
 
-	if algo.Status().Value < core.Running {
-		t.Error("not running expected", algo.Status().Value)
-	}
-
-	err = algo.SetSpace(algo.Space())
+	err = reconfigure()
 
 	if err != nil {
 		t.Error("reconfiguration expected", err)
@@ -1106,4 +1033,4 @@ func DoTestIterToRun(t *testing.T, algo core.OnlineClust) { // must Iter == 0
 	if algo.Status().Value != core.Finished {
 		t.Error("stopped expected", algo.Status().Value)
 	}
-} */
+}
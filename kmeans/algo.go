@@ -1,26 +1,43 @@
 // Package kmeans provides k-means based implementation of online clustering
 package kmeans
 
-import "github.com/wearelumenai/distclus/core"
+import (
+	"io"
+
+	"github.com/wearelumenai/distclus/core"
+)
 
 // NewAlgo creates a new kmeans algo
 func NewAlgo(conf Conf, space core.Space, data []core.Elemt, initializer core.Initializer, args ...interface{}) *core.Algo {
+	return NewWeightedAlgo(conf, space, data, nil, initializer.Weighted(), args...)
+}
+
+// NewWeightedAlgo creates a new kmeans algo with weighted initial data.
+// All weights are 1 if weights is nil
+func NewWeightedAlgo(conf Conf, space core.Space, data []core.Elemt, weights []float64, initializer core.WeightedInitializer, args ...interface{}) *core.Algo {
 	conf.Verify()
-	var impl = getImpl(conf, initializer, data, args)
+	var impl = getImpl(conf, initializer, data, weights, args)
 	return buildAlgo(conf, impl, space)
 }
 
+// Restore creates a kmeans algo with a state written by core.Algo.Snapshot
+func Restore(reader io.Reader, conf Conf, space core.Space, initializer core.Initializer, args ...interface{}) (*core.Algo, error) {
+	conf.Verify()
+	var impl = getImpl(conf, initializer.Weighted(), nil, nil, args)
+	return core.Restore(reader, &conf, &impl, space)
+}
+
 func buildAlgo(conf Conf, impl Impl, space core.Space) (algo *core.Algo) {
 	return core.NewAlgo(&conf, &impl, space)
 }
 
-func getImpl(conf Conf, initializer core.Initializer, data []core.Elemt, args []interface{}) Impl {
-	var implFunc func(Conf, core.Initializer, []core.Elemt, ...interface{}) Impl
+func getImpl(conf Conf, initializer core.WeightedInitializer, data []core.Elemt, weights []float64, args []interface{}) Impl {
+	var implFunc func(Conf, core.WeightedInitializer, []core.Elemt, []float64, ...interface{}) Impl
 	if conf.Par {
-		implFunc = NewParImpl
+		implFunc = NewWeightedParImpl
 	} else {
-		implFunc = NewSeqImpl
+		implFunc = NewWeightedSeqImpl
 	}
-	var impl = implFunc(conf, initializer, data, args...)
+	var impl = implFunc(conf, initializer, data, weights, args...)
 	return impl
 }
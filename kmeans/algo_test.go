@@ -1,10 +1,16 @@
 package kmeans_test
 
 import (
+	"bytes"
+	"errors"
 	"math"
+	"reflect"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/wearelumenai/distclus/core"
+	"github.com/wearelumenai/distclus/euclid"
 	"github.com/wearelumenai/distclus/internal/test"
 	"github.com/wearelumenai/distclus/kmeans"
 )
@@ -60,3 +66,419 @@ func Test_IterToRun(t *testing.T) {
 
 	test.DoTestIterToRun(t, algo)
 }
+
+func Test_SetConf(t *testing.T) {
+	algo := newAlgo(t, core.CtrlConf{}, 10)
+
+	test.DoTestSetConf(t, algo)
+}
+
+func Test_SetConfK(t *testing.T) {
+	var data = make([]core.Elemt, 10)
+	for i := range data {
+		data[i] = []float64{float64(i)}
+	}
+	var algo = kmeans.NewAlgo(kmeans.Conf{K: 3, CtrlConf: core.CtrlConf{Iter: 5}}, space, data, kmeans.GivenInitializer)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var centroids = algo.Centroids()
+	var conf = *algo.Conf().(*kmeans.Conf)
+	conf.K = 5
+
+	err = algo.SetConf(&conf)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var newCentroids = algo.Centroids()
+	if len(newCentroids) != 5 {
+		t.Error("5 centroids expected", newCentroids)
+	}
+	for i := range centroids {
+		if newCentroids[i].([]float64)[0] != centroids[i].([]float64)[0] {
+			t.Error("centroids should be kept", newCentroids, centroids)
+		}
+	}
+
+	conf.K = 2
+
+	err = algo.SetConf(&conf)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if len(algo.Centroids()) != 2 {
+		t.Error("2 centroids expected", algo.Centroids())
+	}
+}
+
+func Test_Snapshot(t *testing.T) {
+	var data = make([]core.Elemt, 10)
+	for i := range data {
+		data[i] = []float64{float64(i)}
+	}
+	var conf = kmeans.Conf{K: 3, FrameSize: 8, CtrlConf: core.CtrlConf{Iter: 5}}
+	var algo = kmeans.NewAlgo(conf, space, data, kmeans.GivenInitializer)
+
+	var err = algo.Play()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	_ = algo.Wait(nil, 0)
+
+	var b bytes.Buffer
+	err = algo.Snapshot(&b)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	restored, err := kmeans.Restore(&b, conf, space, kmeans.GivenInitializer)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if restored.Status().Value != core.Ready {
+		t.Error("ready expected", restored.Status())
+	}
+
+	if !reflect.DeepEqual(restored.Centroids(), algo.Centroids()) {
+		t.Error("same centroids expected", restored.Centroids(), algo.Centroids())
+	}
+
+	if restored.RuntimeFigures()[core.Iterations] != algo.RuntimeFigures()[core.Iterations] {
+		t.Error("same iterations expected", restored.RuntimeFigures(), algo.RuntimeFigures())
+	}
+}
+
+func Test_Predictor(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 10)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var predictor, predictorErr = algo.Predictor()
+	if predictorErr != nil {
+		t.Error("no error expected", predictorErr)
+	}
+	var total = 0
+	for _, card := range predictor.Cardinalities() {
+		total += card
+	}
+
+	if total != 10 {
+		t.Error("10 elements expected", predictor.Cardinalities())
+	}
+}
+
+func Test_PredictBatch(t *testing.T) {
+	var data = make([]core.Elemt, 10)
+	for i := range data {
+		data[i] = []float64{float64(i)}
+	}
+	var algo = kmeans.NewAlgo(kmeans.Conf{K: 3, CtrlConf: core.CtrlConf{Iter: 5}}, space, data, kmeans.GivenInitializer)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var _, labels, dists, _ = algo.PredictBatch(data, 4)
+
+	for i := range data {
+		var _, label, dist = algo.Predict(data[i])
+		if labels[i] != label || dists[i] != dist {
+			t.Error("same prediction expected", labels[i], label, dists[i], dist)
+		}
+	}
+}
+
+func Test_History(t *testing.T) {
+	var data = make([]core.Elemt, 10)
+	for i := range data {
+		data[i] = []float64{float64(i * i)}
+	}
+	var conf = kmeans.Conf{K: 3, CtrlConf: core.CtrlConf{Iter: 10, HistorySize: 3}}
+	var algo = kmeans.NewAlgo(conf, space, data, kmeans.GivenInitializer)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var history = algo.History()
+	if len(history) < 2 || len(history) > 3 {
+		t.Error("2 or 3 models expected", history)
+	}
+
+	var last = history[len(history)-1]
+	if last.Version != algo.Version() || !reflect.DeepEqual(last.Centroids, algo.Centroids()) {
+		t.Error("current model expected", last, algo.Centroids())
+	}
+
+	centroids, err := algo.CentroidsAt(history[0].Version)
+
+	if err != nil || !reflect.DeepEqual(centroids, history[0].Centroids) {
+		t.Error("oldest centroids expected", centroids, err)
+	}
+}
+
+func Test_PushWeighted(t *testing.T) {
+	var conf = kmeans.Conf{K: 2, CtrlConf: core.CtrlConf{Iter: 5}}
+	var data = []core.Elemt{[]float64{0.}, []float64{10.}}
+	var weighted = kmeans.NewWeightedAlgo(conf, space, data, []float64{1., 2.}, core.Initializer(kmeans.GivenInitializer).Weighted())
+	var duplicated = kmeans.NewAlgo(conf, space, append(data, []float64{10.}), kmeans.GivenInitializer)
+
+	for i, weight := range []int{3, 1, 2} {
+		var elemt = []float64{float64(2 * i)}
+		_ = weighted.PushWeighted(elemt, float64(weight))
+		for j := 0; j < weight; j++ {
+			_ = duplicated.Push(elemt)
+		}
+	}
+
+	if err := weighted.Batch(); err != nil {
+		t.Error("no error expected", err)
+	}
+	_ = duplicated.Batch()
+
+	var expected, actual = duplicated.Centroids(), weighted.Centroids()
+	for i := range expected {
+		if math.Abs(expected[i].([]float64)[0]-actual[i].([]float64)[0]) > 1e-9 {
+			t.Error("Expected", expected, "got", actual)
+		}
+	}
+
+	var predictor, predictorErr = weighted.Predictor()
+	if predictorErr != nil {
+		t.Error("no error expected", predictorErr)
+	}
+	if cards := predictor.Cardinalities(); cards[0]+cards[1] != 9 {
+		t.Error("total weight of 9 expected", cards)
+	}
+}
+
+func Test_ConvergenceFinishing(t *testing.T) {
+	var finishing = core.NewOrFinishing(core.NewCentroidShiftFinishing(1e-6), core.NewLossPlateauFinishing(5, .01))
+	var algo = newAlgo(t, core.CtrlConf{Iter: 1000, Finishing: finishing}, 10)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	var figures = algo.RuntimeFigures()
+	if iterations := figures[core.Iterations]; iterations == 0 || iterations >= 1000 {
+		t.Error("convergence expected before 1000 iterations", iterations)
+	}
+	if _, ok := figures[core.Loss]; !ok {
+		t.Error("loss figure expected", figures)
+	}
+}
+
+func Test_IterationPanic(t *testing.T) {
+	var data = []core.Elemt{[]float64{1.}, "not a vector", []float64{2.}}
+	var algo = kmeans.NewAlgo(kmeans.Conf{K: 1, CtrlConf: core.CtrlConf{Iter: 1}}, euclid.Space{}, data, kmeans.GivenInitializer)
+
+	var err = algo.Batch()
+
+	var iterErr *core.IterationError
+	if !errors.As(err, &iterErr) || iterErr.Iteration != 1 || iterErr.Impl != "*kmeans.Impl" || len(iterErr.Stack) == 0 {
+		t.Error("iteration error with stack expected", err)
+	}
+	var conversion *runtime.TypeAssertionError
+	if !errors.As(algo.Status().Error, &conversion) {
+		t.Error("type assertion error expected", algo.Status().Error)
+	}
+}
+
+func Test_StagingFigures(t *testing.T) {
+	var conf = core.CtrlConf{Iter: 1, StagingSize: 2, Overflow: core.DropNewestOverflow}
+	var algo = kmeans.NewAlgo(kmeans.Conf{K: 3, CtrlConf: conf}, euclid.Space{}, test.Vectors, kmeans.GivenInitializer)
+
+	var err = algo.Init()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err = algo.Push(test.Vectors[i]); err != nil {
+			t.Error("no error expected", err)
+		}
+	}
+	var figures = algo.RuntimeFigures()
+	if figures[core.StagedData] != 2 || figures[core.DroppedData] != 3 {
+		t.Error("staged and dropped data expected", figures)
+	}
+}
+
+func Test_PushBatch(t *testing.T) {
+	var conf = core.CtrlConf{Iter: 1, StagingSize: 2, Overflow: core.ErrorOverflow}
+	var algo = kmeans.NewAlgo(kmeans.Conf{K: 3, CtrlConf: conf}, euclid.Space{}, test.Vectors, kmeans.GivenInitializer)
+
+	var err = algo.PushBatch(test.Vectors[:3])
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	err = algo.Init()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	err = algo.PushBatch(test.Vectors[:3])
+
+	var batchErr *core.BatchError
+	if !errors.As(err, &batchErr) || batchErr.Failed != 1 || batchErr.Errors[0] != nil || batchErr.Errors[2] != core.ErrBufferFull {
+		t.Error("partial failure expected", err)
+	}
+	if !errors.Is(err, core.ErrBufferFull) {
+		t.Error("buffer full error expected", err)
+	}
+	if pushed := algo.RuntimeFigures()[core.PushedData]; pushed != 5 {
+		t.Error("5 pushed data expected", pushed)
+	}
+}
+
+func Test_Flush(t *testing.T) {
+	var conf = core.CtrlConf{Iter: 1000, DataPerIter: 1000}
+	var algo = kmeans.NewAlgo(kmeans.Conf{K: 3, CtrlConf: conf}, euclid.Space{}, test.Vectors, kmeans.GivenInitializer)
+	var total = func() (total int) {
+		for _, card := range algo.Impl().(core.CardinalityCounter).Cardinalities(algo) {
+			total += card
+		}
+		return
+	}
+
+	_ = algo.Init()
+	_ = algo.Push(test.Vectors[0])
+
+	if algo.RuntimeFigures()[core.StagedData] != 1 {
+		t.Error("staged data expected", algo.RuntimeFigures())
+	}
+
+	var err = algo.Flush()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	if algo.RuntimeFigures()[core.StagedData] != 0 || total() != 9 {
+		t.Error("applied data expected", algo.RuntimeFigures(), total())
+	}
+
+	_ = algo.Play()
+	_ = algo.Pause()
+	_ = algo.Push(test.Vectors[1])
+	_ = algo.Stop()
+
+	if algo.RuntimeFigures()[core.StagedData] != 0 || total() != 10 {
+		t.Error("applied data expected", algo.RuntimeFigures(), total())
+	}
+
+	err = algo.Init()
+
+	if err != nil || total() != 10 {
+		t.Error("data kept on restart expected", err, total())
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	var algo = kmeans.NewAlgo(kmeans.Conf{K: 3}, euclid.Space{}, test.Vectors, kmeans.GivenInitializer)
+	_ = algo.Init()
+	_, _, _ = algo.Step(3)
+	_ = algo.Push(test.Vectors[3])
+	var version = algo.Version()
+
+	var count, err = algo.Delete(func(elemt core.Elemt) bool {
+		return reflect.DeepEqual(elemt, test.Vectors[3])
+	})
+
+	if err != nil || count != 2 {
+		t.Error("2 deleted elements expected", count, err)
+	}
+	if algo.Version() != version+1 {
+		t.Error("version increment expected", algo.Version())
+	}
+	var dba, _ = core.DBA([]core.Elemt{test.Vectors[0], test.Vectors[4]}, euclid.Space{})
+	test.AssertCentroids(t, core.Clust{dba}, algo.Centroids()[:1])
+
+	count, err = algo.Delete(func(core.Elemt) bool { return false })
+
+	if err != nil || count != 0 || algo.Version() != version+1 {
+		t.Error("nothing deleted expected", count, err, algo.Version())
+	}
+}
+
+func Test_DeleteEmptiedCluster(t *testing.T) {
+	var deleted = []float64{100.}
+	var data = []core.Elemt{[]float64{0.}, deleted, []float64{1.}}
+	var algo = kmeans.NewAlgo(kmeans.Conf{K: 2}, euclid.Space{}, data, kmeans.GivenInitializer)
+	_ = algo.Init()
+	_, _, _ = algo.Step(1)
+
+	var count, err = algo.Delete(func(elemt core.Elemt) bool { return reflect.DeepEqual(elemt, deleted) })
+
+	if err != nil || count != 1 {
+		t.Error("1 deleted element expected", count, err)
+	}
+	var centroids = algo.Centroids()
+	if len(centroids) != 2 {
+		t.Error("2 centroids expected", centroids)
+	}
+	for _, centroid := range centroids {
+		if reflect.DeepEqual(centroid, deleted) {
+			t.Error("no centroid equal to a deleted element expected", centroids)
+		}
+	}
+
+	_, _ = algo.Delete(func(core.Elemt) bool { return true })
+
+	if len(algo.Centroids()) != 0 {
+		t.Error("no centroid expected", algo.Centroids())
+	}
+
+	_ = algo.PushBatch([]core.Elemt{[]float64{5.}, []float64{6.}})
+	_ = algo.Flush()
+	centroids, _, err = algo.Step(1)
+
+	if err != nil || len(centroids) != 2 {
+		t.Error("initialized centroids expected", centroids, err)
+	}
+}
+
+func Test_TTL(t *testing.T) {
+	var conf = kmeans.Conf{K: 3, TTL: time.Hour, FrameSize: 8}
+
+	if conf.Verify() == nil {
+		t.Error("error expected with both FrameSize and TTL")
+	}
+
+	conf.FrameSize = 0
+	var algo = kmeans.NewAlgo(conf, euclid.Space{}, test.Vectors, kmeans.GivenInitializer)
+	_ = algo.Init()
+	_, figures, err := algo.Step(1)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	if _, ok := figures[core.OldestAge]; !ok || figures[core.NewestAge] > figures[core.OldestAge] {
+		t.Error("ages expected", figures)
+	}
+}
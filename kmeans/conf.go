@@ -16,6 +16,7 @@ type Conf struct {
 	Par       bool
 	K         int
 	FrameSize int
+	TTL       time.Duration // maximal age of buffered data, evicted at each iteration. Exclusive with FrameSize
 	RGen      *rand.Rand
 	NumCPU    int // maximal number of CPU to use
 }
@@ -26,12 +27,17 @@ func (conf *Conf) Verify() (err error) {
 	if conf.K < 1 {
 		err = fmt.Errorf("Illegal value for K: %v", conf.K)
 	}
+	if err == nil {
+		err = core.VerifyBuffer(conf.FrameSize, conf.TTL)
+	}
 	return
 }
 
 // SetDefaultValues initializes nil configuration values
 func (conf *Conf) SetDefaultValues() {
-	if conf.RGen == nil {
+	if conf.RGen == nil && conf.Reproducible() {
+		conf.RGen = conf.NewRand(core.ImplStream)
+	} else if conf.RGen == nil {
 		var seed = uint64(time.Now().UTC().Unix())
 		conf.RGen = rand.New(rand.NewSource(seed))
 	}
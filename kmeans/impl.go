@@ -1,6 +1,9 @@
 package kmeans
 
 import (
+	"encoding/gob"
+	"time"
+
 	"github.com/wearelumenai/distclus/core"
 )
 
@@ -8,26 +11,53 @@ import (
 type Impl struct {
 	strategy    Strategy
 	buffer      core.Buffer
-	initializer core.Initializer
+	initializer core.WeightedInitializer
 }
 
-// Strategy Abstract Impl strategy to be implemented by concrete algorithms
+// Strategy Abstract Impl strategy to be implemented by concrete algorithms.
+// Iterate returns the new centroids and the loss of the given centroids, computed while data are assigned
 type Strategy interface {
-	Iterate(space core.Space, centroids core.Clust, data []core.Elemt) core.Clust
+	Iterate(space core.Space, centroids core.Clust, data []core.Elemt, weights []float64) (core.Clust, float64)
 }
 
 // Init Algorithm
 func (impl *Impl) Init(model core.OCModel) (clust core.Clust, err error) {
 	var kmeansConf = model.Conf().(*Conf)
 	_ = impl.buffer.Apply()
-	return impl.initializer(kmeansConf.K, impl.buffer.Data(), model.Space(), kmeansConf.RGen)
+	return impl.initializer(kmeansConf.K, impl.buffer.Data(), impl.buffer.Weights(), model.Space(), kmeansConf.RGen)
 }
 
 // Iterate the algorithm until signal received on closing channel or iteration number is reached
 func (impl *Impl) Iterate(model core.OCModel) (clust core.Clust, runtimeFigures core.RuntimeFigures, err error) {
-	return impl.strategy.Iterate(model.Space(), model.Centroids(), impl.buffer.Data()),
-		nil,
-		impl.buffer.Apply()
+	var space, data, weights = model.Space(), impl.buffer.Data(), impl.buffer.Weights()
+	var centroids = impl.reseed(model.Conf().(*Conf), model.Centroids(), space)
+	if len(centroids) > 0 {
+		var loss float64
+		clust, loss = impl.strategy.Iterate(space, centroids, data, weights)
+		runtimeFigures = core.RuntimeFigures{core.Loss: loss}
+	}
+	err = impl.buffer.Apply()
+	return
+}
+
+// reseed completes with kmeans++ the centroids dropped by a deletion, or initializes them if all were dropped.
+// Centroids are kept as is until there are at least K buffered data
+func (impl *Impl) reseed(conf *Conf, clust core.Clust, space core.Space) core.Clust {
+	var data, weights = impl.buffer.Data(), impl.buffer.Weights()
+	if len(clust) >= conf.K || len(data) < conf.K {
+		return clust
+	}
+	var centroids core.Clust
+	var err error
+	if len(clust) == 0 {
+		centroids, err = impl.initializer(conf.K, data, weights, space, conf.RGen)
+	} else {
+		centroids, err = resize(conf.K, clust, data, weights, space, conf.RGen, conf.NumCPU)
+	}
+	if err != nil {
+		return clust
+	}
+	return centroids
 }
 
 // Push input element in the buffer
@@ -35,9 +65,79 @@ func (impl *Impl) Push(elemt core.Elemt, model core.OCModel) error {
 	return impl.buffer.Push(elemt, model.Status().Alive())
 }
 
+// PushWeighted pushes an input element with its weight in the buffer
+func (impl *Impl) PushWeighted(elemt core.Elemt, weight float64, model core.OCModel) error {
+	return impl.buffer.PushWeighted(elemt, weight, model.Status().Alive())
+}
+
+// PushBatch pushes input elements in the buffer
+func (impl *Impl) PushBatch(elemts []core.Elemt, model core.OCModel) []error {
+	return impl.buffer.PushBatch(elemts, model.Status().Alive())
+}
+
+// Reconfigure takes into account a new configuration, keeping buffered data.
+// Current centroids are kept and completed with kmeans++ or truncated according to the new K
+func (impl *Impl) Reconfigure(model core.OCModel) (clust core.Clust, err error) {
+	var kmeansConf = model.Conf().(*Conf)
+	if kmeansConf.Par {
+		impl.strategy = ParStrategy{Degree: kmeansConf.NumCPU, Stable: kmeansConf.Reproducible()}
+	} else {
+		impl.strategy = &SeqStrategy{Stable: kmeansConf.Reproducible()}
+	}
+	clust = model.Centroids()
+	if clust != nil {
+		clust, err = resize(kmeansConf.K, clust, impl.buffer.Data(), impl.buffer.Weights(), model.Space(), kmeansConf.RGen, kmeansConf.NumCPU)
+	}
+	return
+}
+
+// Flush applies staged data to the buffer
+func (impl *Impl) Flush(model core.OCModel) error {
+	return impl.buffer.Apply()
+}
+
+// Delete removes buffered and staged data matching the predicate, and retracts removed data from the centroids.
+// Centroids of emptied clusters are drawn again with kmeans++ from the remaining data
+func (impl *Impl) Delete(predicate func(core.Elemt) bool, model core.OCModel) (clust core.Clust, count int, err error) {
+	var elemts, weights, staged = impl.buffer.Delete(predicate)
+	clust = model.Centroids()
+	if clust != nil && len(elemts) > 0 {
+		clust = clust.RetractDBA(elemts, weights, impl.buffer.Data(), impl.buffer.Weights(), model.Space())
+		clust = impl.reseed(model.Conf().(*Conf), clust, model.Space())
+	}
+	count = len(elemts) + staged
+	return
+}
+
+// Ages returns the ages of the oldest and newest buffered data if the buffer has a time-to-live
+func (impl *Impl) Ages() (oldest time.Duration, newest time.Duration, timed bool) {
+	return impl.buffer.Ages()
+}
+
+// Cardinalities sums the weights of buffered data of each cluster
+func (impl *Impl) Cardinalities(model core.OCModel) []int {
+	var centroids = model.Centroids()
+	return centroids.WeightedCardinalities(impl.buffer.Data(), impl.buffer.Weights(), model.Space())
+}
+
+// Snapshot writes buffered data
+func (impl *Impl) Snapshot(encoder *gob.Encoder) error {
+	return impl.buffer.Snapshot(encoder)
+}
+
+// Restore reads buffered data written by Snapshot
+func (impl *Impl) Restore(decoder *gob.Decoder) error {
+	return impl.buffer.Restore(decoder)
+}
+
+// Staging returns the staging queue of data pushed while running
+func (impl *Impl) Staging() *core.Staging {
+	return impl.buffer.Staging()
+}
+
 // Copy impl
 func (impl *Impl) Copy(model core.OCModel) (core.Impl, error) {
 	var newConf = model.Conf().(*Conf)
-	var algo = NewAlgo(*newConf, model.Space(), impl.buffer.Data(), impl.initializer)
+	var algo = NewWeightedAlgo(*newConf, model.Space(), impl.buffer.Data(), impl.buffer.Weights(), impl.initializer)
 	return algo.Impl(), nil
 }
@@ -16,6 +16,10 @@ var initializersByNames = map[string]core.Initializer{
 	"rand":  RandInitializer,
 }
 
+func init() {
+	core.RegisterWeightedInitializer(PPInitializer, WeightedPPInitializer)
+}
+
 // CreateInitializer creates an initializer with a name
 func CreateInitializer(name string) core.Initializer {
 	return initializersByNames[strings.ToLower(name)]
@@ -48,15 +52,26 @@ func GivenInitializer(k int, elemts []core.Elemt, space core.Space, _ *rand.Rand
 
 // PPInitializer initializes a clustering algorithm with kmeans++
 func PPInitializer(k int, elemts []core.Elemt, space core.Space, src *rand.Rand) (centroids core.Clust, err error) {
+	return WeightedPPInitializer(k, elemts, nil, space, src)
+}
+
+// WeightedPPInitializer initializes a clustering algorithm with kmeans++.
+// Elements are drawn proportionally to their weights. All weights are 1 if weights is nil
+func WeightedPPInitializer(k int, elemts []core.Elemt, weights []float64, space core.Space, src *rand.Rand) (centroids core.Clust, err error) {
 	err = check(k, elemts)
 	centroids = make(core.Clust, k)
 
 	if err == nil {
-		var draw = src.Intn(len(elemts))
+		var draw int
+		if weights == nil {
+			draw = src.Intn(len(elemts))
+		} else {
+			draw, err = WeightedChoice(weights, src)
+		}
 		centroids[0] = elemts[draw]
 
 		for i := 1; i < k && err == nil; i++ {
-			centroids[i], err = PPIter(centroids[:i], elemts, space, src)
+			centroids[i], err = WeightedPPIter(centroids[:i], elemts, weights, space, src)
 		}
 	}
 
@@ -65,11 +80,49 @@ func PPInitializer(k int, elemts []core.Elemt, space core.Space, src *rand.Rand)
 
 // PPIter runs a kmeans++ iteration : draw an element the does not belong to clust
 func PPIter(clust core.Clust, elemts []core.Elemt, space core.Space, src *rand.Rand) (core.Elemt, error) {
-	var _, dists = clust.ParMapLabel(elemts, space, runtime.NumCPU())
+	return WeightedPPIter(clust, elemts, nil, space, src)
+}
+
+// WeightedPPIter runs a kmeans++ iteration on weighted elements, using all CPU.
+// All weights are 1 if weights is nil
+func WeightedPPIter(clust core.Clust, elemts []core.Elemt, weights []float64, space core.Space, src *rand.Rand) (core.Elemt, error) {
+	return ParPPIter(clust, elemts, weights, space, src, runtime.NumCPU())
+}
+
+// ParPPIter runs a kmeans++ iteration on weighted elements with the given degree of parallelism.
+// All weights are 1 if weights is nil
+func ParPPIter(clust core.Clust, elemts []core.Elemt, weights []float64, space core.Space, src *rand.Rand, degree int) (core.Elemt, error) {
+	if degree < 1 {
+		degree = runtime.NumCPU()
+	}
+	var _, dists = clust.ParMapLabel(elemts, space, degree)
+	if weights != nil {
+		for i := range dists {
+			dists[i] *= weights[i]
+		}
+	}
 	var draw, err = WeightedChoice(dists, src)
 	return space.Copy(elemts[draw]), err
 }
 
+// resize a clustering to k centroids, adding centroids with kmeans++ or removing the last ones
+func resize(k int, clust core.Clust, elemts []core.Elemt, weights []float64, space core.Space, src *rand.Rand, degree int) (centroids core.Clust, err error) {
+	if k < len(clust) {
+		centroids = make(core.Clust, k)
+		copy(centroids, clust)
+	} else {
+		err = check(k, elemts)
+		centroids = make(core.Clust, len(clust), k)
+		copy(centroids, clust)
+		for i := len(clust); i < k && err == nil; i++ {
+			var centroid core.Elemt
+			centroid, err = ParPPIter(centroids, elemts, weights, space, src, degree)
+			centroids = append(centroids, centroid)
+		}
+	}
+	return
+}
+
 // ErrNullSet indicates that a draw over an empty set was attempted
 var ErrNullSet = errors.New("cannot draw over an empty set")
 
@@ -140,3 +140,32 @@ func AssertDistinctCentroids(t *testing.T, clust core.Clust) {
 		}
 	}
 }
+
+func TestWeightedPPInitializer(t *testing.T) {
+	var src = rand.New(rand.NewSource(uint64(time.Now().UTC().Unix())))
+	var weights = make([]float64, len(TestPoints))
+	weights[3] = 5
+	var clust, err = kmeans.WeightedPPInitializer(1, TestPoints, weights, euclid.Space{}, src)
+
+	if err != nil || !reflect.DeepEqual(clust[0], TestPoints[3]) {
+		t.Error("Expected", TestPoints[3], "got", clust, err)
+	}
+}
+
+func TestWeightedPPInitializerFromPP(t *testing.T) {
+	var src = rand.New(rand.NewSource(uint64(time.Now().UTC().Unix())))
+	var weights = make([]float64, len(TestPoints))
+	for i := range weights {
+		weights[i] = 1e-9
+	}
+	weights[3] = 1e9
+	var initializer = core.Initializer(kmeans.PPInitializer).Weighted()
+
+	for i := 0; i < 10; i++ {
+		var clust, err = initializer(1, TestPoints, weights, euclid.Space{}, src)
+
+		if err != nil || !reflect.DeepEqual(clust[0], TestPoints[3]) {
+			t.Error("Expected", TestPoints[3], "got", clust, err)
+		}
+	}
+}
@@ -6,9 +6,15 @@ import (
 
 // NewParImpl parallelizes algorithm implementation
 func NewParImpl(conf Conf, initializer core.Initializer, data []core.Elemt, args ...interface{}) (impl Impl) {
-	impl = NewSeqImpl(conf, initializer, data)
+	return NewWeightedParImpl(conf, initializer.Weighted(), data, nil, args...)
+}
+
+// NewWeightedParImpl parallelizes algorithm implementation on weighted data
+func NewWeightedParImpl(conf Conf, initializer core.WeightedInitializer, data []core.Elemt, weights []float64, args ...interface{}) (impl Impl) {
+	impl = NewWeightedSeqImpl(conf, initializer, data, weights)
 	impl.strategy = ParStrategy{
 		Degree: conf.NumCPU,
+		Stable: conf.Reproducible(),
 	}
 	return
 }
@@ -16,10 +22,15 @@ func NewParImpl(conf Conf, initializer core.Initializer, data []core.Elemt, args
 // ParStrategy parallelizes algorithm strategy
 type ParStrategy struct {
 	Degree int
+	Stable bool // reduce data blocks in a fixed order, so that the result does not depend on the degree
 }
 
 // Iterate processes input cluster
-func (strategy ParStrategy) Iterate(space core.Space, centroids core.Clust, data []core.Elemt) core.Clust {
-	result, _ := centroids.ParReduceDBA(data, space, strategy.Degree)
-	return result
+func (strategy ParStrategy) Iterate(space core.Space, centroids core.Clust, data []core.Elemt, weights []float64) (core.Clust, float64) {
+	if strategy.Stable {
+		result, _, loss := centroids.StableReduceWeightedDBALoss(data, weights, space, 2, strategy.Degree)
+		return result, loss
+	}
+	result, _, loss := centroids.ParReduceWeightedDBALoss(data, weights, space, 2, strategy.Degree)
+	return result, loss
 }
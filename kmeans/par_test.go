@@ -1,6 +1,7 @@
 package kmeans_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/wearelumenai/distclus/core"
@@ -34,3 +35,26 @@ func Test_ParRunAsync(t *testing.T) {
 	test.DoTestRunAsyncCentroids(t, algo)
 	test.DoTestRunAsyncPush(t, algo)
 }
+
+func Test_Reproducible(t *testing.T) {
+	var run = func(par bool, numCPU int) (core.Clust, core.RuntimeFigures) {
+		var conf = kmeans.Conf{K: 3, Par: par, NumCPU: numCPU, CtrlConf: core.CtrlConf{Seed: 42}}
+		var algo = kmeans.NewAlgo(conf, space, test.SpreadVectors(100), kmeans.PPInitializer)
+		_ = algo.Init()
+		var centroids, figures, err = algo.Step(5)
+		test.AssertNoError(t, err)
+		delete(figures, core.Duration)
+		return centroids, figures
+	}
+
+	var centroids, figures = run(false, 1)
+	for _, numCPU := range []int{1, 3, 8} {
+		var parCentroids, parFigures = run(true, numCPU)
+		if !reflect.DeepEqual(centroids, parCentroids) || !reflect.DeepEqual(figures, parFigures) {
+			t.Error("identical centroids expected", numCPU)
+		}
+	}
+	if figures[core.Seed] != 42 {
+		t.Error("seed figure expected", figures)
+	}
+}
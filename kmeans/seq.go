@@ -6,21 +6,31 @@ import (
 
 // NewSeqImpl returns a sequential algorithm execution
 func NewSeqImpl(conf Conf, initializer core.Initializer, data []core.Elemt, args ...interface{}) Impl {
+	return NewWeightedSeqImpl(conf, initializer.Weighted(), data, nil, args...)
+}
+
+// NewWeightedSeqImpl returns a sequential algorithm execution on weighted data
+func NewWeightedSeqImpl(conf Conf, initializer core.WeightedInitializer, data []core.Elemt, weights []float64, args ...interface{}) Impl {
 	return Impl{
-		buffer:      core.NewDataBuffer(data, conf.FrameSize),
-		strategy:    &SeqStrategy{},
+		buffer:      core.NewBuffer(data, weights, conf.FrameSize, conf.TTL, conf.NewStaging()),
+		strategy:    &SeqStrategy{Stable: conf.Reproducible()},
 		initializer: initializer,
 	}
 }
 
 // SeqStrategy defines strategy for sequential execution
 type SeqStrategy struct {
+	Stable bool // reduce data blocks as the parallel strategy does, so that both give identical results
 }
 
 // Iterate processes input cluster
-func (strategy *SeqStrategy) Iterate(space core.Space, centroids core.Clust, data []core.Elemt) core.Clust {
-	var result, _ = centroids.ReduceDBA(data, space)
-	return strategy.buildResult(centroids, result)
+func (strategy *SeqStrategy) Iterate(space core.Space, centroids core.Clust, data []core.Elemt, weights []float64) (core.Clust, float64) {
+	if strategy.Stable {
+		var result, _, loss = centroids.StableReduceWeightedDBALoss(data, weights, space, 2, 1)
+		return result, loss
+	}
+	var result, _, loss = centroids.ReduceWeightedDBALoss(data, weights, space, 2)
+	return strategy.buildResult(centroids, result), loss
 }
 
 func (strategy SeqStrategy) buildResult(centroids core.Clust, result core.Clust) core.Clust {
@@ -67,3 +67,11 @@ func Test_Empty(t *testing.T) {
 
 	test.DoTestEmpty(t, builder)
 }
+
+func Test_Step(t *testing.T) {
+	var implConf = kmeans.Conf{K: 3}
+	var initializer = kmeans.GivenInitializer
+	var algo = kmeans.NewAlgo(implConf, space, []core.Elemt{}, initializer)
+
+	test.DoTestStep(t, algo)
+}
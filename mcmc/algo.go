@@ -1,22 +1,39 @@
 // Package mcmc provides MCMC based implementation of online clustering (cf https://hal.inria.fr/hal-01264233).
 package mcmc
 
-import "github.com/wearelumenai/distclus/core"
+import (
+	"io"
+
+	"github.com/wearelumenai/distclus/core"
+)
 
 // NewAlgo creates a new kmeans algo
 func NewAlgo(conf Conf, space core.Space, data []core.Elemt, initializer core.Initializer, distrib Distrib) *core.Algo {
+	return NewWeightedAlgo(conf, space, data, nil, initializer.Weighted(), distrib)
+}
+
+// NewWeightedAlgo creates a new mcmc algo with weighted initial data.
+// All weights are 1 if weights is nil
+func NewWeightedAlgo(conf Conf, space core.Space, data []core.Elemt, weights []float64, initializer core.WeightedInitializer, distrib Distrib) *core.Algo {
 	conf.Verify()
-	var impl = getImpl(conf, initializer, data, distrib)
+	var impl = getImpl(conf, initializer, data, weights, distrib)
 	return core.NewAlgo(&conf, impl, space)
 }
 
-func getImpl(conf Conf, initializer core.Initializer, data []core.Elemt, distrib Distrib) *Impl {
-	var implFunc func(Conf, core.Initializer, []core.Elemt, Distrib) Impl
+// Restore creates a mcmc algo with a state written by core.Algo.Snapshot
+func Restore(reader io.Reader, conf Conf, space core.Space, initializer core.Initializer, distrib Distrib) (*core.Algo, error) {
+	conf.Verify()
+	var impl = getImpl(conf, initializer.Weighted(), nil, nil, distrib)
+	return core.Restore(reader, &conf, impl, space)
+}
+
+func getImpl(conf Conf, initializer core.WeightedInitializer, data []core.Elemt, weights []float64, distrib Distrib) *Impl {
+	var implFunc func(Conf, core.WeightedInitializer, []core.Elemt, []float64, Distrib) Impl
 	if conf.Par {
-		implFunc = NewParImpl
+		implFunc = NewWeightedParImpl
 	} else {
-		implFunc = NewSeqImpl
+		implFunc = NewWeightedSeqImpl
 	}
-	var impl = implFunc(conf, initializer, data, distrib)
+	var impl = implFunc(conf, initializer, data, weights, distrib)
 	return &impl
 }
@@ -1,7 +1,9 @@
 package mcmc_test
 
 import (
+	"bytes"
 	"math"
+	"reflect"
 	"testing"
 
 	"github.com/wearelumenai/distclus/core"
@@ -78,3 +80,149 @@ func Test_IterToRun(t *testing.T) {
 
 	test.DoTestIterToRun(t, algo)
 }
+
+func Test_SetConf(t *testing.T) {
+	algo := newAlgo(t, core.CtrlConf{}, 10)
+
+	test.DoTestSetConf(t, algo)
+}
+
+func Test_SetConfMaxK(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 10)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var conf = *algo.Conf().(*mcmc.Conf)
+	conf.InitK = 1
+	conf.MaxK = 1
+	conf.Amp = 10
+
+	err = algo.SetConf(&conf)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if len(algo.Centroids()) != 1 {
+		t.Error("1 centroid expected", algo.Centroids())
+	}
+
+	err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if algo.Conf().(*mcmc.Conf).Amp != 10 {
+		t.Error("amp should be reconfigured", algo.Conf())
+	}
+}
+
+func Test_Snapshot(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 5}, 10)
+
+	var err = algo.Play()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	_ = algo.Wait(nil, 0)
+
+	var b bytes.Buffer
+	err = algo.Snapshot(&b)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var conf = mcmc.Conf{InitK: 3, CtrlConf: core.CtrlConf{Iter: 5}}
+	var distrib = mcmc.NewMultivT(mcmc.MultivTConf{Dim: 3})
+	restored, err := mcmc.Restore(&b, conf, space, kmeans.GivenInitializer, distrib)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if restored.Status().Value != core.Ready {
+		t.Error("ready expected", restored.Status())
+	}
+
+	if !reflect.DeepEqual(restored.Centroids(), algo.Centroids()) {
+		t.Error("same centroids expected", restored.Centroids(), algo.Centroids())
+	}
+
+	if !reflect.DeepEqual(restored.RuntimeFigures(), algo.RuntimeFigures()) {
+		t.Error("same figures expected", restored.RuntimeFigures(), algo.RuntimeFigures())
+	}
+}
+
+func Test_SnapshotRandomStreams(t *testing.T) {
+	var conf = mcmc.Conf{InitK: 3, B: 100, Amp: 1, Norm: 2, CtrlConf: core.CtrlConf{Seed: 42}}
+	var newDistrib = func() mcmc.Distrib { return mcmc.NewMultivT(mcmc.MultivTConf{Dim: 5, Nu: 3}) }
+	var algo = mcmc.NewAlgo(conf, space, test.SpreadVectors(10), kmeans.PPInitializer, newDistrib())
+	_ = algo.Init()
+	_, _, _ = algo.Step(5)
+
+	var b bytes.Buffer
+	var err = algo.Snapshot(&b)
+	test.AssertNoError(t, err)
+
+	// random generators are not saved, they restart from the seed at each restoration
+	var restore = func() (core.Clust, core.RuntimeFigures) {
+		var restored, restoreErr = mcmc.Restore(bytes.NewReader(b.Bytes()), conf, space, kmeans.PPInitializer, newDistrib())
+		test.AssertNoError(t, restoreErr)
+		var centroids, figures, stepErr = restored.Step(5)
+		test.AssertNoError(t, stepErr)
+		delete(figures, core.Duration)
+		return centroids, figures
+	}
+
+	var centroids, figures = restore()
+	var otherCentroids, otherFigures = restore()
+	if !reflect.DeepEqual(centroids, otherCentroids) || !reflect.DeepEqual(figures, otherFigures) {
+		t.Error("identical restorations expected", figures, otherFigures)
+	}
+}
+
+func Test_ConvergenceFinishing(t *testing.T) {
+	var finishing = core.NewOrFinishing(core.NewCentroidShiftFinishing(1e-6), core.NewLossPlateauFinishing(5, .01))
+	var algo = newAlgo(t, core.CtrlConf{Iter: 1000, Finishing: finishing}, 10)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	var figures = algo.RuntimeFigures()
+	if iterations := figures[core.Iterations]; iterations == 0 || iterations >= 1000 {
+		t.Error("convergence expected before 1000 iterations", iterations)
+	}
+	if _, ok := figures[core.Loss]; !ok {
+		t.Error("loss figure expected", figures)
+	}
+}
+
+func Test_Trace(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 10, Trace: true}, 10)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var trace = algo.Trace()
+	if len(trace) != 10 {
+		t.Error("10 records expected", len(trace))
+	}
+	for _, record := range trace {
+		if _, ok := record.Figures[mcmc.Acceptations]; !ok || math.IsNaN(record.Loss) || record.K == 0 {
+			t.Error("mcmc figures expected", record)
+		}
+	}
+}
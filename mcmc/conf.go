@@ -22,12 +22,15 @@ type Conf struct {
 	ProbaK         []float64
 	lamb, l2b, tau float64
 	FrameSize      int
-	NumCPU         int // maximal number of CPU to use
+	TTL            time.Duration // maximal age of buffered data, evicted at each iteration. Exclusive with FrameSize
+	NumCPU         int           // maximal number of CPU to use
 }
 
 // SetDefaultValues initializes nil parameter values
 func (conf *Conf) SetDefaultValues() {
-	if conf.RGen == nil {
+	if conf.RGen == nil && conf.Reproducible() {
+		conf.RGen = conf.NewRand(core.ImplStream)
+	} else if conf.RGen == nil {
 		var seed = uint64(time.Now().UTC().Unix())
 		conf.RGen = rand.New(rand.NewSource(seed))
 	}
@@ -57,5 +60,8 @@ func (conf *Conf) Verify() (err error) {
 	if err == nil && conf.InitK > conf.MaxK && conf.MaxK != 0 {
 		err = fmt.Errorf("Illegal value for Max K / Init K: %v / %v", conf.MaxK, conf.InitK)
 	}
+	if err == nil {
+		err = core.VerifyBuffer(conf.FrameSize, conf.TTL)
+	}
 	return
 }
@@ -1,9 +1,27 @@
 package mcmc
 
-import "github.com/wearelumenai/distclus/core"
+import (
+	"github.com/wearelumenai/distclus/core"
+
+	"golang.org/x/exp/rand"
+)
 
 // Distrib defines distribution methods
 type Distrib interface {
 	Sample(mu core.Elemt, time int) core.Elemt
 	Pdf(x, mu core.Elemt, time int) float64
 }
+
+// Reseedable is implemented by distributions which can sample from a given random generator.
+// Reproducible algorithms reseed their distribution with a stream derived from the configuration seed
+type Reseedable interface {
+	Reseed(*rand.Rand) Distrib
+}
+
+// reseed derives the random generator of a distribution from the seed of a reproducible configuration
+func reseed(conf Conf, distrib Distrib) Distrib {
+	if reseedable, ok := distrib.(Reseedable); ok && conf.Reproducible() {
+		distrib = reseedable.Reseed(conf.NewRand(core.DistribStream))
+	}
+	return distrib
+}
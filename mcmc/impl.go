@@ -1,8 +1,11 @@
 package mcmc
 
 import (
+	"encoding/gob"
 	"math"
+	"time"
 
+	"github.com/gonum/floats"
 	"github.com/wearelumenai/distclus/core"
 	"github.com/wearelumenai/distclus/kmeans"
 
@@ -12,7 +15,7 @@ import (
 // Impl of MCMC
 type Impl struct {
 	buffer      core.Buffer
-	initializer core.Initializer
+	initializer core.WeightedInitializer
 	strategy    Strategy
 	uniform     distuv.Uniform
 	distrib     Distrib
@@ -29,14 +32,150 @@ type Impl struct {
 // Copy impl
 func (impl *Impl) Copy(model core.OCModel) (core.Impl, error) {
 	var newConf = model.Conf().(*Conf)
-	var algo = NewAlgo(*newConf, model.Space(), impl.buffer.Data(), impl.initializer, impl.distrib)
+	var algo = NewWeightedAlgo(*newConf, model.Space(), impl.buffer.Data(), impl.buffer.Weights(), impl.initializer, impl.distrib)
 	return algo.Impl(), nil
 }
 
+// Reconfigure takes into account a new configuration, keeping buffered data and current centroids.
+// Centroids are truncated if they exceed the new MaxK
+func (impl *Impl) Reconfigure(model core.OCModel) (clust core.Clust, err error) {
+	var mcmcConf = model.Conf().(*Conf)
+	if mcmcConf.Par {
+		impl.strategy = &ParStrategy{Degree: mcmcConf.NumCPU}
+	} else {
+		impl.strategy = &SeqStrategy{}
+	}
+	impl.uniform.Src = mcmcConf.RGen
+	impl.store.rgen = mcmcConf.RGen
+	impl.store.degree = mcmcConf.NumCPU
+	clust = model.Centroids()
+	if clust != nil {
+		if len(clust) > mcmcConf.MaxK {
+			clust = clust[:mcmcConf.MaxK:mcmcConf.MaxK]
+			impl.store.SetCenters(clust)
+		}
+		var space = model.Space()
+		var data, weights = impl.buffer.Data(), impl.buffer.Weights()
+		impl.current = proposal{
+			k:       len(clust),
+			centers: clust,
+			loss:    impl.strategy.Loss(*mcmcConf, space, clust, data, weights),
+			pdf:     impl.proba(*mcmcConf, space, clust, clust, impl.time),
+		}
+	}
+	return
+}
+
+// Flush applies staged data to the buffer
+func (impl *Impl) Flush(model core.OCModel) error {
+	return impl.buffer.Apply()
+}
+
+// Delete removes buffered and staged data matching the predicate, and retracts removed data from the centroids.
+// Clusters emptied by the deletion are dropped. Stored centers, which may be drawn from removed data, are forgotten
+func (impl *Impl) Delete(predicate func(core.Elemt) bool, model core.OCModel) (clust core.Clust, count int, err error) {
+	var mcmcConf = model.Conf().(*Conf)
+	var elemts, weights, staged = impl.buffer.Delete(predicate)
+	clust = model.Centroids()
+	if clust != nil && len(elemts) > 0 {
+		var space = model.Space()
+		var data, dataWeights = impl.buffer.Data(), impl.buffer.Weights()
+		clust = clust.RetractDBA(elemts, weights, data, dataWeights, space)
+		impl.store = newCenterStore(impl.store.rgen, impl.store.degree)
+		impl.store.SetCenters(clust)
+		impl.current = proposal{
+			k:       len(clust),
+			centers: clust,
+			loss:    impl.strategy.Loss(*mcmcConf, space, clust, data, dataWeights),
+			pdf:     impl.proba(*mcmcConf, space, clust, clust, impl.time),
+		}
+	}
+	count = len(elemts) + staged
+	return
+}
+
+// Ages returns the ages of the oldest and newest buffered data if the buffer has a time-to-live
+func (impl *Impl) Ages() (oldest time.Duration, newest time.Duration, timed bool) {
+	return impl.buffer.Ages()
+}
+
+// Cardinalities sums the weights of buffered data of each cluster
+func (impl *Impl) Cardinalities(model core.OCModel) []int {
+	var centroids = model.Centroids()
+	return centroids.WeightedCardinalities(impl.buffer.Data(), impl.buffer.Weights(), model.Space())
+}
+
+// implSnapshot is the saved state of a mcmc impl
+type implSnapshot struct {
+	K       int
+	Centers core.Clust
+	Loss    float64
+	Pdf     float64
+	Store   map[int]core.Clust
+	Acc     int
+	Lambda  float64
+	Rho     float64
+	RGibbs  float64
+	Time    int
+	Dim     int
+}
+
+// Staging returns the staging queue of data pushed while running
+func (impl *Impl) Staging() *core.Staging {
+	return impl.buffer.Staging()
+}
+
+// Snapshot writes buffered data, the current proposal, stored centers and figures
+func (impl *Impl) Snapshot(encoder *gob.Encoder) (err error) {
+	if err = impl.buffer.Snapshot(encoder); err == nil {
+		err = encoder.Encode(implSnapshot{
+			K:       impl.current.k,
+			Centers: impl.current.centers,
+			Loss:    impl.current.loss,
+			Pdf:     impl.current.pdf,
+			Store:   impl.store.centers,
+			Acc:     impl.acc,
+			Lambda:  impl.lambda,
+			Rho:     impl.rho,
+			RGibbs:  impl.rGibbs,
+			Time:    impl.time,
+			Dim:     impl.dim,
+		})
+	}
+	return
+}
+
+// Restore reads a state written by Snapshot
+func (impl *Impl) Restore(decoder *gob.Decoder) (err error) {
+	var snapshot implSnapshot
+	if err = impl.buffer.Restore(decoder); err == nil {
+		err = decoder.Decode(&snapshot)
+	}
+	if err == nil {
+		impl.current = proposal{
+			k:       snapshot.K,
+			centers: snapshot.Centers,
+			loss:    snapshot.Loss,
+			pdf:     snapshot.Pdf,
+		}
+		impl.store.centers = snapshot.Store
+		if impl.store.centers == nil {
+			impl.store.centers = map[int]core.Clust{}
+		}
+		impl.acc = snapshot.Acc
+		impl.lambda = snapshot.Lambda
+		impl.rho = snapshot.Rho
+		impl.rGibbs = snapshot.RGibbs
+		impl.time = snapshot.Time
+		impl.dim = snapshot.Dim
+	}
+	return
+}
+
 // Strategy specifies strategy methods
 type Strategy interface {
-	Iterate(Conf, core.Space, core.Clust, []core.Elemt, int) core.Clust
-	Loss(Conf, core.Space, core.Clust, []core.Elemt) float64
+	Iterate(Conf, core.Space, core.Clust, []core.Elemt, []float64, int) core.Clust
+	Loss(Conf, core.Space, core.Clust, []core.Elemt, []float64) float64
 }
 
 // Init initializes the algorithm
@@ -44,15 +183,15 @@ func (impl *Impl) Init(model core.OCModel) (centroids core.Clust, err error) {
 	var mcmcConf = model.Conf().(*Conf)
 	var space = model.Space()
 	_ = impl.buffer.Apply()
-	centroids, err = impl.initializer(mcmcConf.InitK, impl.buffer.Data(), space, mcmcConf.RGen)
+	var data, weights = impl.buffer.Data(), impl.buffer.Weights()
+	centroids, err = impl.initializer(mcmcConf.InitK, data, weights, space, mcmcConf.RGen)
 	if err == nil {
-		var data = impl.buffer.Data()
 		impl.dim = space.Dim(centroids)
-		var currentTime = impl.getCurrentTime(data)
+		var currentTime = impl.getCurrentTime(weights)
 		impl.current = proposal{
 			k:       mcmcConf.InitK,
 			centers: centroids,
-			loss:    impl.strategy.Loss(*mcmcConf, space, centroids, data),
+			loss:    impl.strategy.Loss(*mcmcConf, space, centroids, data, weights),
 			pdf:     impl.proba(*mcmcConf, space, centroids, centroids, currentTime),
 		}
 		impl.time = currentTime
@@ -63,16 +202,23 @@ func (impl *Impl) Init(model core.OCModel) (centroids core.Clust, err error) {
 // Iterate executes the algorithm
 func (impl *Impl) Iterate(model core.OCModel) (clust core.Clust, runtimeFigures core.RuntimeFigures, err error) {
 	var mcmcConf = model.Conf().(*Conf)
+	if len(model.Centroids()) == 0 { // every cluster was emptied by a deletion, initialize again with new data
+		if clust, err = impl.Init(model); err != nil {
+			clust, err = nil, nil
+		}
+		return clust, impl.runtimeFigures(), err
+	}
 
-	var data = impl.buffer.Data()
-	var currentTime = impl.getCurrentTime(data)
-	impl.current, clust = impl.doIter(*mcmcConf, model.Space(), impl.current, model.Centroids(), data, currentTime)
+	var data, weights = impl.buffer.Data(), impl.buffer.Weights()
+	var currentTime = impl.getCurrentTime(weights)
+	impl.current, clust = impl.doIter(*mcmcConf, model.Space(), impl.current, model.Centroids(), data, weights, currentTime)
 	impl.time = currentTime
 	return clust, impl.runtimeFigures(), impl.buffer.Apply()
 }
 
-func (impl *Impl) getCurrentTime(data []core.Elemt) int {
-	return len(data)
+// getCurrentTime returns the number of observations, i.e. the total weight of data
+func (impl *Impl) getCurrentTime(weights []float64) int {
+	return int(math.Round(floats.Sum(weights)))
 }
 
 // Push input element in the buffer
@@ -80,6 +226,16 @@ func (impl *Impl) Push(elemt core.Elemt, model core.OCModel) error {
 	return impl.buffer.Push(elemt, model.Status().Alive())
 }
 
+// PushWeighted pushes an input element with its weight in the buffer
+func (impl *Impl) PushWeighted(elemt core.Elemt, weight float64, model core.OCModel) error {
+	return impl.buffer.PushWeighted(elemt, weight, model.Status().Alive())
+}
+
+// PushBatch pushes input elements in the buffer
+func (impl *Impl) PushBatch(elemts []core.Elemt, model core.OCModel) []error {
+	return impl.buffer.PushBatch(elemts, model.Status().Alive())
+}
+
 type proposal struct {
 	k       int
 	centers core.Clust
@@ -87,8 +243,8 @@ type proposal struct {
 	pdf     float64
 }
 
-func (impl *Impl) doIter(conf Conf, space core.Space, current proposal, centroids core.Clust, data []core.Elemt, time int) (proposal, core.Clust) {
-	var prop = impl.propose(conf, space, current, centroids, data, time)
+func (impl *Impl) doIter(conf Conf, space core.Space, current proposal, centroids core.Clust, data []core.Elemt, weights []float64, time int) (proposal, core.Clust) {
+	var prop = impl.propose(conf, space, current, centroids, data, weights, time)
 
 	if impl.accept(conf, current, prop, time) {
 		current = prop
@@ -100,24 +256,24 @@ func (impl *Impl) doIter(conf Conf, space core.Space, current proposal, centroid
 	return current, centroids
 }
 
-func (impl *Impl) propose(conf Conf, space core.Space, current proposal, centroids core.Clust, data []core.Elemt, time int) proposal {
-	k, centers := impl.getKCenters(conf, space, current, centroids, data)
+func (impl *Impl) propose(conf Conf, space core.Space, current proposal, centroids core.Clust, data []core.Elemt, weights []float64, time int) proposal {
+	k, centers := impl.getKCenters(conf, space, current, centroids, data, weights)
 	centers = impl.alter(conf, space, centers, time)
-	centers = impl.strategy.Iterate(conf, space, centers, data, 1)
+	centers = impl.strategy.Iterate(conf, space, centers, data, weights, 1)
 	return proposal{
 		k:       k,
 		centers: centers,
-		loss:    impl.strategy.Loss(conf, space, centers, data),
+		loss:    impl.strategy.Loss(conf, space, centers, data, weights),
 		pdf:     impl.proba(conf, space, centers, centers, time),
 	}
 }
 
-func (impl *Impl) getKCenters(conf Conf, space core.Space, current proposal, centroids core.Clust, data []core.Elemt) (int, core.Clust) {
+func (impl *Impl) getKCenters(conf Conf, space core.Space, current proposal, centroids core.Clust, data []core.Elemt, weights []float64) (int, core.Clust) {
 	var k = impl.nextK(conf, current.k, data)
-	var centers, err = impl.store.GetCenters(data, space, k, centroids)
+	var centers, err = impl.store.GetWeightedCenters(data, weights, space, k, centroids)
 	if err != nil {
 		k = current.k
-		centers, _ = impl.store.GetCenters(data, space, k, centroids)
+		centers, _ = impl.store.GetWeightedCenters(data, weights, space, k, centroids)
 	}
 	return k, centers
 }
@@ -180,5 +336,6 @@ func (impl *Impl) runtimeFigures() core.RuntimeFigures {
 		Rho:          impl.rho,
 		RGibbs:       impl.rGibbs,
 		Time:         float64(impl.time),
+		core.Loss:    impl.current.loss,
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"sync"
 
 	"github.com/wearelumenai/distclus/core"
+
+	"golang.org/x/exp/rand"
 )
 
 // DistribBuilder represents functor that build a Distrib from data
@@ -15,6 +17,7 @@ type LateDistrib struct {
 	initialized bool
 	mu          sync.Mutex
 	distrib     Distrib
+	src         *rand.Rand
 }
 
 // NewLateDistrib creates a new LateDistrib instance
@@ -48,6 +51,20 @@ func (d *LateDistrib) tryInitialize(elemt core.Elemt) {
 	defer d.mu.Unlock()
 	if !d.initialized {
 		d.distrib = d.initializer(elemt)
+		if reseedable, ok := d.distrib.(Reseedable); ok && d.src != nil {
+			d.distrib = reseedable.Reseed(d.src)
+		}
 		d.initialized = true
 	}
 }
+
+// Reseed sets the random generator of the wrapped Distrib once initialized
+func (d *LateDistrib) Reseed(src *rand.Rand) Distrib {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.src = src
+	if reseedable, ok := d.distrib.(Reseedable); ok && d.initialized {
+		d.distrib = reseedable.Reseed(src)
+	}
+	return d
+}
@@ -61,6 +61,13 @@ func (m *MultivT) setConf(conf MultivTConf) {
 	}
 }
 
+// Reseed returns the same distribution sampling from the given random generator
+func (m MultivT) Reseed(src *rand.Rand) Distrib {
+	var conf = m.MultivTConf
+	conf.RGen = src
+	return NewMultivT(conf)
+}
+
 // Sample from a (uncorrelated) multivariate t distribution
 func (m MultivT) Sample(mu core.Elemt, time int) core.Elemt {
 	var fmu = mu.([]float64)
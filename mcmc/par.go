@@ -2,12 +2,16 @@ package mcmc
 
 import (
 	"github.com/wearelumenai/distclus/core"
-	"github.com/wearelumenai/distclus/kmeans"
 )
 
 // NewParImpl returns a new parallelized algorithm implementation
 func NewParImpl(conf Conf, initializer core.Initializer, data []core.Elemt, distrib Distrib) (impl Impl) {
-	impl = NewSeqImpl(conf, initializer, data, distrib)
+	return NewWeightedParImpl(conf, initializer.Weighted(), data, nil, distrib)
+}
+
+// NewWeightedParImpl returns a new parallelized algorithm implementation on weighted data
+func NewWeightedParImpl(conf Conf, initializer core.WeightedInitializer, data []core.Elemt, weights []float64, distrib Distrib) (impl Impl) {
+	impl = NewWeightedSeqImpl(conf, initializer, data, weights, distrib)
 	impl.strategy = &ParStrategy{
 		Degree: conf.NumCPU,
 	}
@@ -20,27 +24,14 @@ type ParStrategy struct {
 }
 
 // Iterate is the iterative execution
-func (strategy *ParStrategy) Iterate(conf Conf, space core.Space, centroids core.Clust, data []core.Elemt, iter int) (result core.Clust) {
-	var kmeansConf = kmeans.Conf{
-		Par: true,
-		K:   len(centroids),
-		CtrlConf: core.CtrlConf{
-			Iter: iter,
-		},
-	}
-	core.PrepareConf(&kmeansConf)
-	var model = core.NewSimpleOCModel(&kmeansConf, space, core.NewOCStatus(core.Created), core.RuntimeFigures{}, result)
-	var impl = kmeans.NewParImpl(kmeansConf, centroids.Initializer, data)
-	result, _ = impl.Init(model)
-	for i := 0; i < iter; i++ {
-		model = core.NewSimpleOCModel(&kmeansConf, space, core.NewOCStatus(core.Created), core.RuntimeFigures{}, result)
-		result, _, _ = impl.Iterate(model)
-	}
-
-	return
+func (strategy *ParStrategy) Iterate(conf Conf, space core.Space, centroids core.Clust, data []core.Elemt, weights []float64, iter int) core.Clust {
+	return iterateKMeans(conf, space, centroids, data, weights, iter)
 }
 
 // Loss calculates loss for the given proposal and data in parallel
-func (strategy *ParStrategy) Loss(conf Conf, space core.Space, centroids core.Clust, data []core.Elemt) float64 {
-	return centroids.ParTotalLoss(data, space, conf.Norm, strategy.Degree)
+func (strategy *ParStrategy) Loss(conf Conf, space core.Space, centroids core.Clust, data []core.Elemt, weights []float64) float64 {
+	if conf.Reproducible() {
+		return centroids.StableWeightedTotalLoss(data, weights, space, conf.Norm, strategy.Degree)
+	}
+	return centroids.ParWeightedTotalLoss(data, weights, space, conf.Norm, strategy.Degree)
 }
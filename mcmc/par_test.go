@@ -2,6 +2,7 @@ package mcmc_test
 
 import (
 	"math"
+	"reflect"
 	"runtime"
 	"testing"
 
@@ -109,7 +110,7 @@ func TestParStrategy_Loss(t *testing.T) {
 	strategy.Degree = runtime.NumCPU()
 
 	var clust = algo.Centroids()
-	var l1 = strategy.Loss(implConf, algo.Space(), clust, buffer.Data())
+	var l1 = strategy.Loss(implConf, algo.Space(), clust, buffer.Data(), buffer.Weights())
 	var l2 = clust.TotalLoss(test.Vectors, algo.Space(), implConf.Norm)
 
 	if math.Abs(l1-l2) > 1e-6 {
@@ -162,3 +163,31 @@ func AssertDistance(t *testing.T, center core.Elemt, actual core.Elemt) {
 		t.Error("to far from distribution center")
 	}
 }
+
+func Test_Reproducible(t *testing.T) {
+	var run = func(par bool, numCPU int) (core.Clust, core.RuntimeFigures) {
+		var conf = mcmc.Conf{
+			InitK: 3, B: 100, Amp: 1, Norm: 2,
+			Par: par, NumCPU: numCPU,
+			CtrlConf: core.CtrlConf{Seed: 42},
+		}
+		var distrib = mcmc.NewMultivT(mcmc.MultivTConf{Dim: 5, Nu: 3})
+		var algo = mcmc.NewAlgo(conf, space, test.SpreadVectors(100), kmeans.PPInitializer, distrib)
+		_ = algo.Init()
+		var centroids, figures, err = algo.Step(20)
+		test.AssertNoError(t, err)
+		delete(figures, core.Duration)
+		return centroids, figures
+	}
+
+	var centroids, figures = run(false, 1)
+	for _, numCPU := range []int{1, 3, 8} {
+		var parCentroids, parFigures = run(true, numCPU)
+		if !reflect.DeepEqual(centroids, parCentroids) || !reflect.DeepEqual(figures, parFigures) {
+			t.Error("identical results expected", numCPU, figures, parFigures)
+		}
+	}
+	if figures[core.Seed] != 42 {
+		t.Error("seed figure expected", figures)
+	}
+}
@@ -9,13 +9,18 @@ import (
 
 // NewSeqImpl returns a sequantial mcmc implementation
 func NewSeqImpl(conf Conf, initializer core.Initializer, data []core.Elemt, distrib Distrib) Impl {
+	return NewWeightedSeqImpl(conf, initializer.Weighted(), data, nil, distrib)
+}
+
+// NewWeightedSeqImpl returns a sequantial mcmc implementation on weighted data
+func NewWeightedSeqImpl(conf Conf, initializer core.WeightedInitializer, data []core.Elemt, weights []float64, distrib Distrib) Impl {
 	return Impl{
-		buffer:      core.NewDataBuffer(data, conf.FrameSize),
+		buffer:      core.NewBuffer(data, weights, conf.FrameSize, conf.TTL, conf.NewStaging()),
 		initializer: initializer,
 		uniform:     distuv.Uniform{Max: 1, Min: 0, Src: conf.RGen},
-		store:       NewCenterStore(conf.RGen),
+		store:       newCenterStore(conf.RGen, conf.NumCPU),
 		strategy:    &SeqStrategy{},
-		distrib:     distrib,
+		distrib:     reseed(conf, distrib),
 	}
 }
 
@@ -24,27 +29,24 @@ type SeqStrategy struct {
 }
 
 // Iterate execute the algorithm
-func (strategy *SeqStrategy) Iterate(conf Conf, space core.Space, centroids core.Clust, data []core.Elemt, iter int) (result core.Clust) {
-	var kmeansConf = kmeans.Conf{
-		K:    len(centroids),
-		RGen: conf.RGen,
-		CtrlConf: core.CtrlConf{
-			Iter: iter,
-		},
+func (strategy *SeqStrategy) Iterate(conf Conf, space core.Space, centroids core.Clust, data []core.Elemt, weights []float64, iter int) core.Clust {
+	return iterateKMeans(conf, space, centroids, data, weights, iter)
+}
+
+// Loss calculates loss for the given proposal and data
+func (strategy *SeqStrategy) Loss(conf Conf, space core.Space, proposal core.Clust, data []core.Elemt, weights []float64) float64 {
+	if conf.Reproducible() {
+		return proposal.StableWeightedTotalLoss(data, weights, space, conf.Norm, 1)
 	}
-	core.PrepareConf(&kmeansConf)
-	var model = core.NewSimpleOCModel(&kmeansConf, space, core.NewOCStatus(core.Created), core.RuntimeFigures{}, result)
-	var impl = kmeans.NewParImpl(kmeansConf, centroids.Initializer, data)
-	result, _ = impl.Init(model)
+	return proposal.WeightedTotalLoss(data, weights, space, conf.Norm)
+}
+
+// iterateKMeans runs iter kmeans iterations from the given centroids
+func iterateKMeans(conf Conf, space core.Space, centroids core.Clust, data []core.Elemt, weights []float64, iter int) (result core.Clust) {
+	var strategy = kmeans.ParStrategy{Degree: conf.NumCPU, Stable: conf.Reproducible()}
+	result = centroids
 	for i := 0; i < iter; i++ {
-		model = core.NewSimpleOCModel(&kmeansConf, space, core.NewOCStatus(core.Created), core.RuntimeFigures{}, result)
-		result, _, _ = impl.Iterate(model)
+		result, _ = strategy.Iterate(space, result, data, weights)
 	}
-
 	return
 }
-
-// Loss calculates loss for the given proposal and data
-func (strategy *SeqStrategy) Loss(conf Conf, space core.Space, proposal core.Clust, data []core.Elemt) float64 {
-	return proposal.TotalLoss(data, space, conf.Norm)
-}
@@ -184,3 +184,87 @@ func Test_AcceptRatio(t *testing.T) {
 		t.Error("Expected ratio in [0 1], got", r)
 	}
 }
+
+func Test_Step(t *testing.T) {
+	var implConf = mcmc.Conf{
+		InitK: 3,
+		RGen:  rand.New(rand.NewSource(6305689164243)),
+		B:     100, Amp: 1,
+		Norm:      2,
+		FrameSize: 8,
+	}
+	var tConf = mcmc.MultivTConf{
+		Dim: 5,
+		Nu:  3,
+	}
+	var distrib = mcmc.NewMultivT(tConf)
+	var initializer = kmeans.GivenInitializer
+	var algo = mcmc.NewAlgo(implConf, space, []core.Elemt{}, initializer, distrib)
+
+	test.DoTestStep(t, algo)
+}
+
+func Test_Delete(t *testing.T) {
+	var implConf = mcmc.Conf{
+		InitK: 3,
+		RGen:  rand.New(rand.NewSource(6305689164243)),
+		B:     100, Amp: 1,
+		Norm: 2,
+	}
+	var distrib = mcmc.NewMultivT(mcmc.MultivTConf{Dim: 5, Nu: 3})
+	var algo = mcmc.NewAlgo(implConf, space, test.Vectors, kmeans.GivenInitializer, distrib)
+	_ = algo.Init()
+	_, _, _ = algo.Step(10)
+	var version = algo.Version()
+
+	var count, err = algo.Delete(func(elemt core.Elemt) bool {
+		return elemt.([]float64)[0] > 40
+	})
+
+	if err != nil || count != 3 || algo.Version() != version+1 {
+		t.Error("3 deleted elements expected", count, err, algo.Version())
+	}
+
+	_, _, err = algo.Step(10)
+
+	if err != nil || algo.RuntimeFigures()[core.Iterations] != 20 {
+		t.Error("no error expected", err)
+	}
+}
+
+func Test_DeleteEmptiedCluster(t *testing.T) {
+	var implConf = mcmc.Conf{
+		InitK: 3,
+		RGen:  rand.New(rand.NewSource(6305689164243)),
+		B:     100, Amp: 1,
+		Norm: 2,
+	}
+	var distrib = mcmc.NewMultivT(mcmc.MultivTConf{Dim: 5, Nu: 3})
+	var algo = mcmc.NewAlgo(implConf, space, test.Vectors, kmeans.GivenInitializer, distrib)
+	_ = algo.Init()
+	var outlier = func(elemt core.Elemt) bool { return elemt.([]float64)[0] > 40 }
+
+	var _, err = algo.Delete(outlier)
+
+	if err != nil || len(algo.Centroids()) != 2 {
+		t.Error("2 centroids expected", algo.Centroids(), err)
+	}
+	for _, centroid := range algo.Centroids() {
+		if outlier(centroid) {
+			t.Error("no centroid equal to a deleted element expected", algo.Centroids())
+		}
+	}
+
+	_, _, err = algo.Step(5)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	_, _ = algo.Delete(func(core.Elemt) bool { return true })
+	_, _, err = algo.Step(1)
+
+	if err != nil || len(algo.Centroids()) != 0 {
+		t.Error("no centroid expected", algo.Centroids(), err)
+	}
+}
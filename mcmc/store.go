@@ -1,6 +1,8 @@
 package mcmc
 
 import (
+	"runtime"
+
 	"github.com/wearelumenai/distclus/core"
 	"github.com/wearelumenai/distclus/kmeans"
 
@@ -11,22 +13,34 @@ import (
 type CenterStore struct {
 	centers map[int]core.Clust
 	rgen    *rand.Rand
+	degree  int
 }
 
 // NewCenterStore returns a new center store
 func NewCenterStore(rgen *rand.Rand) CenterStore {
+	return newCenterStore(rgen, runtime.NumCPU())
+}
+
+// newCenterStore returns a new center store drawing centers with the given degree of parallelism
+func newCenterStore(rgen *rand.Rand, degree int) CenterStore {
 	return CenterStore{
 		centers: map[int]core.Clust{},
 		rgen:    rgen,
+		degree:  degree,
 	}
 }
 
 // GetCenters returns input centroids centers
 func (store *CenterStore) GetCenters(data []core.Elemt, space core.Space, k int, clust core.Clust) (core.Clust, error) {
+	return store.GetWeightedCenters(data, nil, space, k, clust)
+}
+
+// GetWeightedCenters returns input centroids centers, new centers are drawn from weighted data
+func (store *CenterStore) GetWeightedCenters(data []core.Elemt, weights []float64, space core.Space, k int, clust core.Clust) (core.Clust, error) {
 	var centers, ok = store.centers[k]
 
 	if !ok {
-		return store.genCenters(data, space, k, clust)
+		return store.genCenters(data, weights, space, k, clust)
 	}
 
 	return centers, nil
@@ -37,12 +51,12 @@ func (store *CenterStore) SetCenters(clust core.Clust) {
 	store.centers[len(clust)] = clust
 }
 
-func (store *CenterStore) genCenters(data []core.Elemt, space core.Space, k int, prev core.Clust) (clust core.Clust, err error) {
+func (store *CenterStore) genCenters(data []core.Elemt, weights []float64, space core.Space, k int, prev core.Clust) (clust core.Clust, err error) {
 	var prevK = len(prev)
 
 	switch {
 	case prevK < k:
-		clust, err = store.addCenter(data, space, prevK, prev)
+		clust, err = store.addCenter(data, weights, space, prevK, prev)
 
 	case prevK > k:
 		clust = store.delCenter(space, prevK, prev)
@@ -54,12 +68,12 @@ func (store *CenterStore) genCenters(data []core.Elemt, space core.Space, k int,
 	return
 }
 
-func (store *CenterStore) addCenter(data []core.Elemt, space core.Space, prevK int, prev core.Clust) (clust core.Clust, err error) {
+func (store *CenterStore) addCenter(data []core.Elemt, weights []float64, space core.Space, prevK int, prev core.Clust) (clust core.Clust, err error) {
 	clust = make(core.Clust, prevK+1)
 	for i := 0; i < prevK; i++ {
 		clust[i] = space.Copy(prev[i])
 	}
-	clust[prevK], err = kmeans.PPIter(prev, data, space, store.rgen)
+	clust[prevK], err = kmeans.ParPPIter(prev, data, weights, space, store.rgen, store.degree)
 	return
 }
 
@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+// ContentType of the Prometheus text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Prefix of all metric names
+const Prefix = "distclus_"
+
+// sample is a metric line
+type sample struct {
+	suffix string
+	labels []string // pairs of label name and value
+	value  float64
+}
+
+// family is a group of samples sharing a metric name
+type family struct {
+	name    string
+	help    string
+	kind    string
+	samples []sample
+}
+
+// Write the metrics of all registered algorithms in the Prometheus text exposition format
+func (registry *Registry) Write(writer io.Writer) (err error) {
+	var buffered = bufio.NewWriter(writer)
+	for _, f := range registry.families() {
+		f.write(buffered)
+	}
+	return buffered.Flush()
+}
+
+// families gathers the metrics of all registered algorithms
+func (registry *Registry) families() (families []*family) {
+	var algos = registry.list()
+	var status = &family{name: Prefix + "status", kind: "gauge", help: "Algorithm status, 1 for the current one"}
+	var clusters = &family{name: Prefix + "clusters", kind: "gauge", help: "Number of clusters"}
+	var pushes = &family{name: Prefix + "pushes_total", kind: "counter", help: "Number of pushed elements"}
+	var pushErrors = &family{name: Prefix + "push_errors_total", kind: "counter", help: "Number of elements which could not be pushed"}
+	var predictions = &family{name: Prefix + "predictions_total", kind: "counter", help: "Number of predicted elements"}
+	var pushLatency = &family{name: Prefix + "push_duration_seconds", kind: "histogram", help: "Push call latency"}
+	var predictLatency = &family{name: Prefix + "predict_duration_seconds", kind: "histogram", help: "Predict call latency"}
+	var figures = map[string]*family{}
+	for _, algo := range algos {
+		var current = algo.Status().Value
+		for value := core.Created; value <= core.Finished; value++ {
+			var gauge float64
+			if value == current {
+				gauge = 1
+			}
+			status.add("", gauge, "algo", algo.name, "status", value.String())
+		}
+		clusters.add("", float64(len(algo.Centroids())), "algo", algo.name)
+		for key, value := range algo.RuntimeFigures() {
+			var name = Prefix + metricName(key)
+			if figures[name] == nil {
+				figures[name] = &family{name: name, kind: "gauge", help: "Runtime figure " + key}
+			}
+			figures[name].add("", value, "algo", algo.name)
+		}
+		pushes.add("", float64(atomic.LoadUint64(&algo.pushes)), "algo", algo.name)
+		pushErrors.add("", float64(atomic.LoadUint64(&algo.pushErrors)), "algo", algo.name)
+		predictions.add("", float64(atomic.LoadUint64(&algo.predictions)), "algo", algo.name)
+		pushLatency.addHistogram(algo.pushLatency.snapshot(), "algo", algo.name)
+		predictLatency.addHistogram(algo.predictLatency.snapshot(), "algo", algo.name)
+	}
+	families = []*family{status, clusters}
+	var names = make([]string, 0, len(figures))
+	for name := range figures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		families = append(families, figures[name])
+	}
+	return append(families, pushes, pushErrors, predictions, pushLatency, predictLatency)
+}
+
+// add a sample to the family
+func (f *family) add(suffix string, value float64, labels ...string) {
+	f.samples = append(f.samples, sample{suffix: suffix, labels: labels, value: value})
+}
+
+// addHistogram adds the bucket, sum and count samples of a histogram
+func (f *family) addHistogram(snapshot histogramSnapshot, labels ...string) {
+	for i, count := range snapshot.counts {
+		var bound = "+Inf"
+		if i < len(snapshot.bounds) {
+			bound = formatValue(snapshot.bounds[i])
+		}
+		f.add("_bucket", float64(count), append(labels[:len(labels):len(labels)], "le", bound)...)
+	}
+	f.add("_sum", snapshot.sum, labels...)
+	f.add("_count", float64(snapshot.count), labels...)
+}
+
+// write the family in the text exposition format
+func (f *family) write(writer *bufio.Writer) {
+	if len(f.samples) == 0 {
+		return
+	}
+	fmt.Fprintf(writer, "# HELP %s %s\n", f.name, escape(f.help, false))
+	fmt.Fprintf(writer, "# TYPE %s %s\n", f.name, f.kind)
+	for _, s := range f.samples {
+		writer.WriteString(f.name + s.suffix)
+		if len(s.labels) > 0 {
+			writer.WriteByte('{')
+			for i := 0; i < len(s.labels); i += 2 {
+				if i > 0 {
+					writer.WriteByte(',')
+				}
+				fmt.Fprintf(writer, "%s=\"%s\"", s.labels[i], escape(s.labels[i+1], true))
+			}
+			writer.WriteByte('}')
+		}
+		writer.WriteByte(' ')
+		writer.WriteString(formatValue(s.value))
+		writer.WriteByte('\n')
+	}
+}
+
+// metricName converts a camel case figure name to a snake case metric name
+func metricName(key string) string {
+	var builder strings.Builder
+	for i, r := range key {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(unicode.ToLower(r))
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) && i > 0):
+			builder.WriteRune(r)
+		default:
+			builder.WriteByte('_')
+		}
+	}
+	return builder.String()
+}
+
+// escape help texts and label values
+func escape(value string, quote bool) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, "\n", `\n`, -1)
+	if quote {
+		value = strings.Replace(value, `"`, `\"`, -1)
+	}
+	return value
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the latency histograms
+var DefaultBuckets = []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1, .5, 1}
+
+// histogram counts observed latencies in cumulative buckets
+type histogram struct {
+	bounds []float64
+	counts []uint64 // count per bucket, the last one is +Inf
+	sum    float64
+	count  uint64
+	mutex  sync.Mutex
+}
+
+// histogramSnapshot is a copy of a histogram with cumulative counts
+type histogramSnapshot struct {
+	bounds []float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+// observe a duration
+func (h *histogram) observe(duration time.Duration) {
+	var seconds = duration.Seconds()
+	var i = 0
+	for i < len(h.bounds) && seconds > h.bounds[i] {
+		i++
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.counts[i]++
+	h.sum += seconds
+	h.count++
+}
+
+// snapshot returns the cumulative counts of the histogram
+func (h *histogram) snapshot() (snapshot histogramSnapshot) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	snapshot = histogramSnapshot{
+		bounds: h.bounds,
+		counts: make([]uint64, len(h.counts)),
+		sum:    h.sum,
+		count:  h.count,
+	}
+	var cumulative uint64
+	for i, count := range h.counts {
+		cumulative += count
+		snapshot.counts[i] = cumulative
+	}
+	return
+}
@@ -0,0 +1,161 @@
+// Package metrics exposes running algorithms in the Prometheus text exposition format.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wearelumenai/distclus/core"
+)
+
+// ErrDuplicate raised when registering an algorithm with a name already in use
+var ErrDuplicate = errors.New("algorithm name already registered")
+
+// ErrName raised when registering an algorithm with an empty name
+var ErrName = errors.New("algorithm name must not be empty")
+
+// Registry of named algorithms to scrape
+type Registry struct {
+	algos map[string]*Algo
+	mutex sync.RWMutex
+}
+
+// Algo is a registered algorithm which counts and times push and predict calls.
+// It must be used in place of the registered algorithm for these calls to be measured
+type Algo struct {
+	core.OnlineClust
+	name           string
+	pushes         uint64
+	pushErrors     uint64
+	predictions    uint64
+	pushLatency    *histogram
+	predictLatency *histogram
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{algos: map[string]*Algo{}}
+}
+
+// Register an algorithm with a name, which is given as the algo label of its metrics
+func (registry *Registry) Register(name string, algo core.OnlineClust) (instrumented *Algo, err error) {
+	if name == "" {
+		return nil, ErrName
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if _, ok := registry.algos[name]; ok {
+		return nil, ErrDuplicate
+	}
+	instrumented = &Algo{
+		OnlineClust:    algo,
+		name:           name,
+		pushLatency:    newHistogram(DefaultBuckets),
+		predictLatency: newHistogram(DefaultBuckets),
+	}
+	registry.algos[name] = instrumented
+	return
+}
+
+// Unregister an algorithm by name
+func (registry *Registry) Unregister(name string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	delete(registry.algos, name)
+}
+
+// Get returns a registered algorithm by name
+func (registry *Registry) Get(name string) (algo *Algo, ok bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	algo, ok = registry.algos[name]
+	return
+}
+
+// list registered algorithms sorted by name
+func (registry *Registry) list() (algos []*Algo) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	algos = make([]*Algo, 0, len(registry.algos))
+	for _, algo := range registry.algos {
+		algos = append(algos, algo)
+	}
+	sort.Slice(algos, func(i, j int) bool { return algos[i].name < algos[j].name })
+	return
+}
+
+// ServeHTTP writes the metrics of all registered algorithms
+func (registry *Registry) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", ContentType)
+	_ = registry.Write(writer)
+}
+
+// Name returns the registration name of the algorithm
+func (algo *Algo) Name() string {
+	return algo.name
+}
+
+// Push an element and measures the call
+func (algo *Algo) Push(elemt core.Elemt) (err error) {
+	var start = time.Now()
+	err = algo.OnlineClust.Push(elemt)
+	algo.pushed(start, err)
+	return
+}
+
+// PushWeighted pushes an element with a weight and measures the call
+func (algo *Algo) PushWeighted(elemt core.Elemt, weight float64) (err error) {
+	var start = time.Now()
+	err = algo.OnlineClust.PushWeighted(elemt, weight)
+	algo.pushed(start, err)
+	return
+}
+
+// PushBatch pushes elements and measures the call, each element counting as a push
+func (algo *Algo) PushBatch(elemts []core.Elemt) (err error) {
+	var start = time.Now()
+	err = algo.OnlineClust.PushBatch(elemts)
+	var failed int
+	if batchErr, ok := err.(*core.BatchError); ok {
+		failed = batchErr.Failed
+	} else if err != nil {
+		failed = len(elemts)
+	}
+	algo.pushLatency.observe(time.Now().Sub(start))
+	atomic.AddUint64(&algo.pushes, uint64(len(elemts)))
+	atomic.AddUint64(&algo.pushErrors, uint64(failed))
+	return
+}
+
+// Predict the cluster of an element and measures the call
+func (algo *Algo) Predict(elemt core.Elemt) (pred core.Elemt, label int, dist float64) {
+	var start = time.Now()
+	pred, label, dist = algo.OnlineClust.Predict(elemt)
+	algo.predicted(start, 1)
+	return
+}
+
+// PredictBatch predicts the clusters of elements and measures the call, each element counting as a prediction
+func (algo *Algo) PredictBatch(elemts []core.Elemt, degree int) (centroids core.Clust, labels []int, dists []float64, version int) {
+	var start = time.Now()
+	centroids, labels, dists, version = algo.OnlineClust.PredictBatch(elemts, degree)
+	algo.predicted(start, len(elemts))
+	return
+}
+
+func (algo *Algo) pushed(start time.Time, err error) {
+	algo.pushLatency.observe(time.Now().Sub(start))
+	atomic.AddUint64(&algo.pushes, 1)
+	if err != nil {
+		atomic.AddUint64(&algo.pushErrors, 1)
+	}
+}
+
+func (algo *Algo) predicted(start time.Time, count int) {
+	algo.predictLatency.observe(time.Now().Sub(start))
+	atomic.AddUint64(&algo.predictions, uint64(count))
+}
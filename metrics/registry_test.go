@@ -0,0 +1,108 @@
+package metrics_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wearelumenai/distclus/core"
+	"github.com/wearelumenai/distclus/euclid"
+	"github.com/wearelumenai/distclus/internal/test"
+	"github.com/wearelumenai/distclus/kmeans"
+	"github.com/wearelumenai/distclus/metrics"
+)
+
+func newAlgo(registry *metrics.Registry, name string) *metrics.Algo {
+	var conf = kmeans.Conf{K: 3, CtrlConf: core.CtrlConf{Iter: 5}}
+	var algo = kmeans.NewAlgo(conf, euclid.Space{}, []core.Elemt{}, kmeans.GivenInitializer)
+	var instrumented, err = registry.Register(name, algo)
+	if err != nil {
+		panic(err)
+	}
+	return instrumented
+}
+
+func Test_Register(t *testing.T) {
+	var registry = metrics.NewRegistry()
+	var algo = newAlgo(registry, "km")
+
+	if _, err := registry.Register("km", algo); err != metrics.ErrDuplicate {
+		t.Error("duplicate error expected", err)
+	}
+	if _, err := registry.Register("", algo); err != metrics.ErrName {
+		t.Error("name error expected", err)
+	}
+	if registered, ok := registry.Get("km"); !ok || registered != algo {
+		t.Error("registered algo expected")
+	}
+
+	registry.Unregister("km")
+
+	if _, ok := registry.Get("km"); ok {
+		t.Error("unregistered algo expected")
+	}
+}
+
+func Test_Write(t *testing.T) {
+	var registry = metrics.NewRegistry()
+	var algo = newAlgo(registry, "km")
+	newAlgo(registry, `a"b`)
+
+	for _, elemt := range test.Vectors {
+		_ = algo.Push(elemt)
+	}
+	var err = algo.Batch()
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	algo.Predict(test.Vectors[0])
+	algo.PredictBatch(test.Vectors[:3], 1)
+
+	var buffer bytes.Buffer
+	err = registry.Write(&buffer)
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	var text = buffer.String()
+	for _, line := range []string{
+		"# TYPE distclus_status gauge",
+		`distclus_status{algo="km",status="Finished"} 1`,
+		`distclus_status{algo="km",status="Running"} 0`,
+		`distclus_status{algo="a\"b",status="Created"} 1`,
+		`distclus_clusters{algo="km"} 3`,
+		`distclus_iterations{algo="km"} 5`,
+		`distclus_pushed_data{algo="km"} 8`,
+		"# TYPE distclus_duration gauge",
+		"# TYPE distclus_pushes_total counter",
+		`distclus_pushes_total{algo="km"} 8`,
+		`distclus_push_errors_total{algo="km"} 0`,
+		`distclus_predictions_total{algo="km"} 4`,
+		"# TYPE distclus_push_duration_seconds histogram",
+		`distclus_push_duration_seconds_bucket{algo="km",le="+Inf"} 8`,
+		`distclus_push_duration_seconds_count{algo="km"} 8`,
+		`distclus_predict_duration_seconds_count{algo="km"} 2`,
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Error("line expected", line)
+		}
+	}
+	if strings.Count(text, "# TYPE distclus_status ") != 1 {
+		t.Error("one family per metric expected")
+	}
+}
+
+func Test_ServeHTTP(t *testing.T) {
+	var registry = metrics.NewRegistry()
+	newAlgo(registry, "km")
+
+	var recorder = httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if recorder.Header().Get("Content-Type") != metrics.ContentType {
+		t.Error("text exposition format expected", recorder.Header())
+	}
+	if !strings.Contains(recorder.Body.String(), `distclus_clusters{algo="km"} 0`) {
+		t.Error("metrics expected", recorder.Body.String())
+	}
+}
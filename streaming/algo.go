@@ -1,17 +1,34 @@
 package streaming
 
-import "github.com/wearelumenai/distclus/core"
+import (
+	"io"
+
+	"github.com/wearelumenai/distclus/core"
+)
 
 // NewAlgo creates a new algorithm with a streaming implementation
 func NewAlgo(conf Conf, space core.Space, data []core.Elemt) *core.Algo {
+	return NewWeightedAlgo(conf, space, data, nil)
+}
+
+// NewWeightedAlgo creates a new algorithm with a streaming implementation and weighted initial data.
+// All weights are 1 if weights is nil
+func NewWeightedAlgo(conf Conf, space core.Space, data []core.Elemt, weights []float64) *core.Algo {
 	conf.Verify()
 	if conf.BufferSize < len(data) {
 		panic("buffer size must be greater than initial data")
 	}
-	var impl = getImpl(conf, data)
+	var impl = getImpl(conf, data, weights)
 	return core.NewAlgo(&conf, &impl, space)
 }
 
-func getImpl(conf Conf, elemts []core.Elemt) Impl {
-	return NewImpl(conf, elemts)
+// Restore creates a streaming algo with a state written by core.Algo.Snapshot
+func Restore(reader io.Reader, conf Conf, space core.Space) (*core.Algo, error) {
+	conf.Verify()
+	var impl = getImpl(conf, nil, nil)
+	return core.Restore(reader, &conf, &impl, space)
+}
+
+func getImpl(conf Conf, elemts []core.Elemt, weights []float64) Impl {
+	return NewWeightedImpl(conf, elemts, weights)
 }
@@ -1,6 +1,8 @@
 package streaming_test
 
 import (
+	"bytes"
+	"reflect"
 	"testing"
 
 	"github.com/wearelumenai/distclus/core"
@@ -219,3 +221,97 @@ func Test_IterToRun(t *testing.T) {
 	test.DoTestIterToRun(t, algo)
 }
 */
+
+func Test_SetConf(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{}, 10)
+
+	test.DoTestSetConf(t, algo)
+}
+
+func Test_SetConfOutRatio(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{}, 10)
+
+	var err = algo.Init()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var conf = *algo.Conf().(*streaming.Conf)
+	conf.OutRatio = 3
+
+	err = algo.SetConf(&conf)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if algo.Conf().(*streaming.Conf).OutRatio != 3 {
+		t.Error("out ratio should be reconfigured", algo.Conf())
+	}
+
+	conf.BufferSize++
+
+	err = algo.SetConf(&conf)
+
+	if err == nil {
+		t.Error("error expected")
+	}
+}
+
+func Test_Snapshot(t *testing.T) {
+	var algo = newAlgo(t, core.CtrlConf{Iter: 9}, 10)
+
+	var err = algo.Init()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var b bytes.Buffer
+	err = algo.Snapshot(&b)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	var conf = streaming.Conf{CtrlConf: core.CtrlConf{Iter: 9}, BufferSize: 20}
+	restored, err := streaming.Restore(&b, conf, euclid.Space{})
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if !reflect.DeepEqual(restored.Centroids(), algo.Centroids()) {
+		t.Error("same centroids expected", restored.Centroids(), algo.Centroids())
+	}
+
+	_ = restored.Play()
+	err = restored.Wait(nil, 0)
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+
+	if restored.RuntimeFigures()[core.Iterations] != 9 {
+		t.Error("buffered elements expected", restored.RuntimeFigures())
+	}
+}
+
+func Test_ConvergenceFinishing(t *testing.T) {
+	var finishing = core.NewOrFinishing(core.NewCentroidShiftFinishing(1e-6), core.NewLossPlateauFinishing(5, .01))
+	var algo = newAlgo(t, core.CtrlConf{Iter: 1000, Finishing: finishing}, 10)
+
+	var err = algo.Batch()
+
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	var figures = algo.RuntimeFigures()
+	if iterations := figures[core.Iterations]; iterations == 0 || iterations >= 1000 {
+		t.Error("convergence expected before 1000 iterations", iterations)
+	}
+	if _, ok := figures[core.Loss]; !ok {
+		t.Error("loss figure expected", figures)
+	}
+}
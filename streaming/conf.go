@@ -34,7 +34,9 @@ func (conf *Conf) SetDefaultValues() {
 	if conf.OutAfter == 0 {
 		conf.OutAfter = 5
 	}
-	if conf.RGen == nil {
+	if conf.RGen == nil && conf.Reproducible() {
+		conf.RGen = conf.NewRand(core.ImplStream)
+	} else if conf.RGen == nil {
 		conf.RGen = rand.New(rand.NewSource(uint64(time.Now().Nanosecond())))
 	}
 	if conf.Sigma == 0 {
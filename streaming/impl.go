@@ -1,7 +1,9 @@
 package streaming
 
 import (
+	"encoding/gob"
 	"errors"
+	"math"
 
 	"github.com/wearelumenai/distclus/core"
 
@@ -12,11 +14,14 @@ import (
 type Impl struct {
 	maxDistance float64
 	clust       core.Clust
-	cards       []int
-	c           chan core.Elemt
+	cards       []float64 // total weight of each cluster
+	staging     *core.Staging
 	conf        Conf
 	norm        distuv.Normal
 	count       int
+	loss        float64              // weighted sum of squared distances between processed elements and their nearest cluster
+	weight      float64              // total weight of processed elements
+	pending     []core.WeightedElemt // buffered elements restored from a snapshot, processed before staged ones
 }
 
 // Copy impl
@@ -26,15 +31,48 @@ func (impl *Impl) Copy(model core.OCModel) (core.Impl, error) {
 	return algo.Impl(), nil
 }
 
+// Reconfigure takes into account a new configuration, keeping buffered data and current clusters.
+// The buffer size can not be changed
+func (impl *Impl) Reconfigure(model core.OCModel) (clust core.Clust, err error) {
+	var conf = model.Conf().(*Conf)
+	if conf.BufferSize != impl.conf.BufferSize {
+		err = errors.New("buffer size can not be changed")
+	} else {
+		impl.conf = *conf
+		impl.norm = distuv.Normal{
+			Mu:    conf.Mu,
+			Sigma: conf.Sigma,
+			Src:   conf.RGen,
+		}
+		clust = model.Centroids()
+	}
+	return
+}
+
 // NewImpl creates a new Impl instance.
 func NewImpl(conf Conf, elemts []core.Elemt) Impl {
-	var c = make(chan core.Elemt, conf.BufferSize)
+	return NewWeightedImpl(conf, elemts, nil)
+}
+
+// NewWeightedImpl creates a new Impl instance with weighted elements.
+// All weights are 1 if weights is nil.
+// Elements are buffered with the configured overflow policy, by default a push fails when the buffer is full
+func NewWeightedImpl(conf Conf, elemts []core.Elemt, weights []float64) Impl {
+	var overflow = conf.Overflow
+	if overflow == core.DefaultOverflow {
+		overflow = core.ErrorOverflow
+	}
+	var staging = core.NewStaging(conf.BufferSize, overflow, conf.OverflowTimeout)
 	for i := range elemts {
-		c <- elemts[i]
+		var weight = 1.
+		if weights != nil {
+			weight = weights[i]
+		}
+		_ = staging.Push(elemts[i], weight)
 	}
 	return Impl{
-		c:    c,
-		conf: conf,
+		staging: staging,
+		conf:    conf,
 		norm: distuv.Normal{
 			Mu:    conf.Mu,
 			Sigma: conf.Sigma,
@@ -45,13 +83,10 @@ func NewImpl(conf Conf, elemts []core.Elemt) Impl {
 
 // Init initializes the streaming algorithm.
 func (impl *Impl) Init(model core.OCModel) (clust core.Clust, err error) {
-	select {
-	case centroids := <-impl.c:
-		clust = core.Clust{centroids}
-		for i := range clust {
-			impl.AddCenter(clust[i], 0.)
-		}
-	default:
+	if first, ok := impl.next(); ok {
+		clust = core.Clust{first.Elemt}
+		impl.addCenter(first.Elemt, first.Weight, 0.)
+	} else {
 		err = errors.New("at least one element is needed")
 	}
 	return
@@ -59,26 +94,107 @@ func (impl *Impl) Init(model core.OCModel) (clust core.Clust, err error) {
 
 // Iterate runs the streaming algorithm.
 func (impl *Impl) Iterate(model core.OCModel) (clust core.Clust, runtimeFigures core.RuntimeFigures, err error) {
-	select {
-	case elemt := <-impl.c:
-		impl.Process(elemt, model.Space())
-		clust = impl.clust
-	default:
+	if elemt, ok := impl.next(); ok {
+		impl.process(elemt.Elemt, elemt.Weight, model.Space())
+		clust = make(core.Clust, len(impl.clust))
+		copy(clust, impl.clust)
 	}
 	runtimeFigures = impl.runtimeFigures()
 	return
 }
 
+// Cardinalities returns the total weight of processed elements of each cluster
+func (impl *Impl) Cardinalities(model core.OCModel) []int {
+	var cards = make([]int, len(impl.cards))
+	for i, card := range impl.cards {
+		cards[i] = int(math.Round(card))
+	}
+	return cards
+}
+
+// next returns the next buffered element if any
+func (impl *Impl) next() (elemt core.WeightedElemt, ok bool) {
+	if len(impl.pending) > 0 {
+		elemt, ok = impl.pending[0], true
+		impl.pending = impl.pending[1:]
+	} else if impl.staging != nil {
+		elemt, ok = impl.staging.Next()
+	}
+	return
+}
+
+// Staging returns the buffer of pushed elements
+func (impl *Impl) Staging() *core.Staging {
+	return impl.staging
+}
+
+// implSnapshot is the saved state of a streaming impl
+type implSnapshot struct {
+	MaxDistance float64
+	Clust       core.Clust
+	Cards       []float64
+	Count       int
+	Loss        float64
+	Weight      float64
+	Buffer      []core.WeightedElemt
+}
+
+// Snapshot writes clusters, cardinalities, figures and buffered elements, which stay buffered
+func (impl *Impl) Snapshot(encoder *gob.Encoder) error {
+	var buffer = append(append([]core.WeightedElemt{}, impl.pending...), impl.staging.Staged()...)
+	return encoder.Encode(implSnapshot{
+		MaxDistance: impl.maxDistance,
+		Clust:       impl.clust,
+		Cards:       impl.cards,
+		Count:       impl.count,
+		Loss:        impl.loss,
+		Weight:      impl.weight,
+		Buffer:      buffer,
+	})
+}
+
+// Restore reads a state written by Snapshot
+func (impl *Impl) Restore(decoder *gob.Decoder) (err error) {
+	var snapshot implSnapshot
+	if err = decoder.Decode(&snapshot); err == nil {
+		impl.maxDistance = snapshot.MaxDistance
+		impl.clust = snapshot.Clust
+		impl.cards = snapshot.Cards
+		impl.count = snapshot.Count
+		impl.loss = snapshot.Loss
+		impl.weight = snapshot.Weight
+		impl.pending = snapshot.Buffer
+	}
+	return
+}
+
 func (impl *Impl) runtimeFigures() core.RuntimeFigures {
-	return core.RuntimeFigures{MaxDistance: impl.maxDistance}
+	var figures = core.RuntimeFigures{MaxDistance: impl.maxDistance}
+	if impl.weight > 0 {
+		figures[core.Loss] = impl.loss / impl.weight
+	}
+	return figures
 }
 
 // Push pushes a new element
 func (impl *Impl) Push(elemt core.Elemt, model core.OCModel) (err error) {
-	select {
-	case impl.c <- elemt:
-	default:
-		err = errors.New("buffer is full")
+	return impl.PushWeighted(elemt, 1, model)
+}
+
+// PushWeighted pushes a new element with its weight
+func (impl *Impl) PushWeighted(elemt core.Elemt, weight float64, model core.OCModel) (err error) {
+	return impl.staging.Push(elemt, weight)
+}
+
+// PushBatch pushes new elements
+func (impl *Impl) PushBatch(elemts []core.Elemt, model core.OCModel) (errs []error) {
+	for i, elemt := range elemts {
+		if err := impl.staging.Push(elemt, 1); err != nil {
+			if errs == nil {
+				errs = make([]error, len(elemts))
+			}
+			errs[i] = err
+		}
 	}
 	return
 }
@@ -105,22 +221,34 @@ func (impl *Impl) GetRelativeDistance(distance float64) float64 {
 
 // AddCenter adds a new center.
 func (impl *Impl) AddCenter(cluster core.Elemt, distance float64) {
+	impl.addCenter(cluster, 1, distance)
+}
+
+func (impl *Impl) addCenter(cluster core.Elemt, weight float64, distance float64) {
 	impl.clust = append(impl.clust, cluster)
-	impl.cards = append(impl.cards, 1)
+	impl.cards = append(impl.cards, weight)
 	impl.UpdateMaxDistance(distance)
 }
 
 // AddOutlier adds an outlier.
 func (impl *Impl) AddOutlier(outlier core.Elemt) {
+	impl.addOutlier(outlier, 1)
+}
+
+func (impl *Impl) addOutlier(outlier core.Elemt, weight float64) {
 	impl.clust = append(impl.clust, outlier)
-	impl.cards = append(impl.cards, 1)
+	impl.cards = append(impl.cards, weight)
 }
 
 // UpdateCenter modifies an existing center.
 func (impl *Impl) UpdateCenter(label int, elemt core.Elemt, distance float64, space core.Space) {
-	var cluster = space.Combine(impl.clust[label], impl.cards[label], elemt, 1)
+	impl.updateCenter(label, elemt, 1, distance, space)
+}
+
+func (impl *Impl) updateCenter(label int, elemt core.Elemt, weight float64, distance float64, space core.Space) {
+	var cluster = core.WeightedCombine(space, impl.clust[label], impl.cards[label], elemt, weight)
 	impl.clust[label] = cluster
-	impl.cards[label]++
+	impl.cards[label] += weight
 	impl.UpdateMaxDistance(distance)
 }
 
@@ -131,17 +259,24 @@ func (impl *Impl) GetClusters() core.Clust {
 
 // Process a streaming iteration.
 func (impl *Impl) Process(elemt core.Elemt, space core.Space) {
+	impl.process(elemt, 1, space)
+}
+
+// process a streaming iteration with a weighted element
+func (impl *Impl) process(elemt core.Elemt, weight float64, space core.Space) {
 	var _, label, distance = impl.clust.Assign(elemt, space)
 	var relative = impl.GetRelativeDistance(distance)
+	impl.loss += weight * distance * distance
+	impl.weight += weight
 
 	if impl.count >= impl.conf.OutAfter && relative > impl.conf.OutRatio {
-		impl.AddOutlier(elemt)
+		impl.addOutlier(elemt, weight)
 	} else {
 		var threshold = impl.norm.Rand()
 		if threshold < relative {
-			impl.AddCenter(elemt, distance)
+			impl.addCenter(elemt, weight, distance)
 		} else {
-			impl.UpdateCenter(label, elemt, distance, space)
+			impl.updateCenter(label, elemt, weight, distance, space)
 		}
 	}
 	impl.count++
@@ -171,6 +171,22 @@ var conf = streaming.Conf{
 	RGen: rand.New(rand.NewSource(1514613616431)),
 }
 
+func TestImpl_CopyUnitWeights(t *testing.T) {
+	var impl = streaming.NewWeightedImpl(conf, []core.Elemt{[]float64{1.}}, []float64{4.})
+	var clust, _ = impl.Init(NewInitModel(&conf))
+
+	var copied, err = impl.Copy(NewIterateModel(&conf, clust))
+	if err != nil {
+		t.Error("no error expected", err)
+	}
+	var model = NewIterateModel(&conf, clust)
+	_, _ = copied.Init(model)
+	var cards = copied.(core.CardinalityCounter).Cardinalities(model)
+	if !reflect.DeepEqual([]int{1}, cards) {
+		t.Error("unit weights expected got", cards)
+	}
+}
+
 func TestImpl_Iterate(t *testing.T) {
 	var distr = mix()
 	var impl = streaming.NewImpl(conf, []core.Elemt{})
@@ -29,6 +29,11 @@ func (impl *Impl) Push(core.Elemt, core.OCModel) (err error) {
 	return
 }
 
+// Reconfigure takes into account a new configuration or space without losing the impl state (optional)
+func (impl *Impl) Reconfigure(model core.OCModel) (clust core.Clust, err error) {
+	return model.Centroids(), nil
+}
+
 // Copy the impl
 func (impl *Impl) Copy(core.OCModel) (core.Impl, error) {
 	return impl, nil